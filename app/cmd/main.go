@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	_ "time/tzdata"
 
 	"github.com/bagus2x/tjiwi/app/handler"
 	appMiddleware "github.com/bagus2x/tjiwi/app/middleware"
@@ -9,14 +10,36 @@ import (
 	"github.com/bagus2x/tjiwi/db"
 	basepaperrepo "github.com/bagus2x/tjiwi/pkg/basepaper/repository"
 	basepaperservice "github.com/bagus2x/tjiwi/pkg/basepaper/service"
+	deliveryorderrepo "github.com/bagus2x/tjiwi/pkg/deliveryorder/repository"
+	deliveryorderservice "github.com/bagus2x/tjiwi/pkg/deliveryorder/service"
 	historyrepo "github.com/bagus2x/tjiwi/pkg/history/repository"
 	historyservice "github.com/bagus2x/tjiwi/pkg/history/service"
+	labelservice "github.com/bagus2x/tjiwi/pkg/label/service"
+	locationrepo "github.com/bagus2x/tjiwi/pkg/location/repository"
+	locationservice "github.com/bagus2x/tjiwi/pkg/location/service"
+	materialrepo "github.com/bagus2x/tjiwi/pkg/material/repository"
+	materialservice "github.com/bagus2x/tjiwi/pkg/material/service"
+	purchaseorderrepo "github.com/bagus2x/tjiwi/pkg/purchaseorder/repository"
+	purchaseorderservice "github.com/bagus2x/tjiwi/pkg/purchaseorder/service"
+	reportrepo "github.com/bagus2x/tjiwi/pkg/report/repository"
+	reportservice "github.com/bagus2x/tjiwi/pkg/report/service"
+	reservationrepo "github.com/bagus2x/tjiwi/pkg/reservation/repository"
+	reservationservice "github.com/bagus2x/tjiwi/pkg/reservation/service"
+	rollrepo "github.com/bagus2x/tjiwi/pkg/roll/repository"
+	rollservice "github.com/bagus2x/tjiwi/pkg/roll/service"
+	scanservice "github.com/bagus2x/tjiwi/pkg/scan/service"
+	stocktakerepo "github.com/bagus2x/tjiwi/pkg/stocktake/repository"
+	stocktakeservice "github.com/bagus2x/tjiwi/pkg/stocktake/service"
 	storageRepo "github.com/bagus2x/tjiwi/pkg/storage/repository"
 	storageService "github.com/bagus2x/tjiwi/pkg/storage/service"
 	stormembRepo "github.com/bagus2x/tjiwi/pkg/storagemember/repository"
 	stormembService "github.com/bagus2x/tjiwi/pkg/storagemember/service"
+	thresholdrepo "github.com/bagus2x/tjiwi/pkg/threshold/repository"
+	thresholdservice "github.com/bagus2x/tjiwi/pkg/threshold/service"
 	userrepo "github.com/bagus2x/tjiwi/pkg/user/repository"
 	userservice "github.com/bagus2x/tjiwi/pkg/user/service"
+	valuationrepo "github.com/bagus2x/tjiwi/pkg/valuation/repository"
+	valuationservice "github.com/bagus2x/tjiwi/pkg/valuation/service"
 	"github.com/gin-gonic/gin"
 )
 
@@ -31,12 +54,34 @@ func main() {
 	stormembRepo := stormembRepo.New(database)
 	basePaperRepo := basepaperrepo.New(database)
 	historyRepo := historyrepo.New(database)
+	stockTakeRepo := stocktakerepo.New(database)
+	reservationRepo := reservationrepo.New(database)
+	deliveryOrderRepo := deliveryorderrepo.New(database)
+	purchaseOrderRepo := purchaseorderrepo.New(database)
+	thresholdRepo := thresholdrepo.New(database)
+	locationRepo := locationrepo.New(database)
+	materialRepo := materialrepo.New(database)
+	valuationRepo := valuationrepo.New(database)
+	rollRepo := rollrepo.New(database)
+	reportRepo := reportrepo.New(database)
 
 	userService := userservice.New(userRepo, cfg)
 	stormembService := stormembService.New(stormembRepo, cfg)
 	storageService := storageService.New(storageRepo, stormembRepo, cfg)
-	basePaperService := basepaperservice.New(basePaperRepo, historyRepo)
-	historyService := historyservice.New(historyRepo)
+	thresholdService := thresholdservice.New(thresholdRepo, basePaperRepo, stormembRepo)
+	valuationService := valuationservice.New(valuationRepo, storageRepo, stormembRepo, materialRepo)
+	basePaperService := basepaperservice.New(basePaperRepo, historyRepo, stormembRepo, reservationRepo, thresholdService, locationRepo, materialRepo, rollRepo, storageRepo, valuationService, purchaseOrderRepo)
+	historyService := historyservice.New(historyRepo, materialRepo, rollRepo)
+	stockTakeService := stocktakeservice.New(stockTakeRepo, basePaperRepo, historyRepo, stormembRepo, thresholdService, locationRepo, valuationService, rollRepo, materialRepo, reservationRepo)
+	reservationService := reservationservice.New(reservationRepo, basePaperRepo, stormembRepo)
+	deliveryOrderService := deliveryorderservice.New(deliveryOrderRepo, basePaperRepo, stormembRepo, reservationRepo, basePaperService)
+	purchaseOrderService := purchaseorderservice.New(purchaseOrderRepo, stormembRepo, basePaperService)
+	locationService := locationservice.New(locationRepo, basePaperRepo, stormembRepo)
+	materialService := materialservice.New(materialRepo, stormembRepo)
+	rollService := rollservice.New(rollRepo, basePaperRepo, stormembRepo)
+	labelService := labelservice.New(basePaperRepo, rollRepo, locationRepo, materialRepo, stormembRepo)
+	reportService := reportservice.New(reportRepo, basePaperRepo, stormembRepo)
+	scanService := scanservice.New(basePaperRepo, rollRepo, locationRepo, stockTakeRepo, stormembRepo)
 
 	mw := appMiddleware.New(userService, stormembService)
 
@@ -50,12 +95,36 @@ func main() {
 	stormembGroup := app.Group("/storagemembers")
 	basePaper := app.Group("/basepapers")
 	history := app.Group("/histories")
+	stockTake := app.Group("/stocktakes")
+	reservation := app.Group("/reservations")
+	deliveryOrder := app.Group("/deliveryorders")
+	purchaseOrder := app.Group("/purchaseorders")
+	threshold := app.Group("/thresholds")
+	valuation := app.Group("/valuations")
+	location := app.Group("/locations")
+	material := app.Group("/materials")
+	roll := app.Group("/rolls")
+	label := app.Group("/labels")
+	scan := app.Group("/scans")
+	report := app.Group("/reports")
 
 	handler.User(userGroup, userService, mw)
 	handler.Storage(storageGroup, storageService, mw)
 	handler.StorageMember(stormembGroup, stormembService, mw)
 	handler.BasePaper(basePaper, basePaperService, mw)
 	handler.History(history, historyService, mw)
+	handler.StockTake(stockTake, stockTakeService, mw)
+	handler.Reservation(reservation, reservationService, mw)
+	handler.DeliveryOrder(deliveryOrder, deliveryOrderService, mw)
+	handler.PurchaseOrder(purchaseOrder, purchaseOrderService, mw)
+	handler.Threshold(threshold, thresholdService, mw)
+	handler.Valuation(valuation, valuationService, mw)
+	handler.Location(location, locationService, mw)
+	handler.Material(material, materialService, mw)
+	handler.Roll(roll, rollService, mw)
+	handler.Label(label, labelService, mw)
+	handler.Scan(scan, scanService, mw)
+	handler.Report(report, reportService, mw)
 
 	log.Fatal(app.Run(cfg.AppPort()))
 }
@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"fmt"
+	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/bagus2x/tjiwi/app"
 	"github.com/bagus2x/tjiwi/app/middleware"
@@ -10,13 +13,23 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+const maxImportSize = 10 << 20
+
 func BasePaper(r *gin.RouterGroup, service basepaper.Service, mw *middleware.Middleware) {
 	r.PUT("", mw.AuthJWT(), mw.MustBeStorageMember(false, true), addBasePaper(service))
+	r.POST("/storage/:storageID/import", mw.AuthJWT(), mw.MustBeStorageMember(false, true), importBasePapers(service))
 	r.GET("/:basePaperID", mw.AuthJWT(), mw.MustBeStorageMember(false, true), getBasePaper(service))
 	r.GET("/storage/:storageID/search-in-buffer-area", mw.AuthJWT(), mw.MustBeStorageMember(false, true), searchInBufferArea(service))
 	r.GET("/storage/:storageID/search-in-list", mw.AuthJWT(), mw.MustBeStorageMember(false, true), searchInList(service))
+	r.GET("/storage/:storageID/export", mw.AuthJWT(), mw.MustBeStorageMember(false, true), exportBasePapers(service))
 	r.PUT("/:basePaperID/move-to-list", mw.AuthJWT(), mw.MustBeStorageMember(false, true), moveToList(service))
+	r.PUT("/:basePaperID/relocate", mw.AuthJWT(), mw.MustBeStorageMember(false, true), relocate(service))
+	r.PUT("/:basePaperID/transfer", mw.AuthJWT(), mw.MustBeStorageMember(false, true), transfer(service))
+	r.GET("/storage/:storageID/pick-plan", mw.AuthJWT(), mw.MustBeStorageMember(false, true), pickPlan(service))
 	r.PUT("/:basePaperID/deliver", mw.AuthJWT(), mw.MustBeStorageMember(false, true), deliver(service))
+	r.PUT("/:basePaperID/adjust", mw.AuthJWT(), mw.MustBeStorageMember(true, true), adjust(service))
+	r.PUT("/return", mw.AuthJWT(), mw.MustBeStorageMember(false, true), returnBasePaper(service))
+	r.PUT("/reverse", mw.AuthJWT(), mw.MustBeStorageMember(true, true), reverseHistory(service))
 	r.DELETE("/:basePaperID", mw.AuthJWT(), mw.MustBeStorageMember(true, true), deleteBasePaper(service))
 }
 
@@ -56,6 +69,75 @@ func addBasePaper(service basepaper.Service) gin.HandlerFunc {
 	}
 }
 
+func importBasePapers(service basepaper.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		storageID, err := strconv.ParseInt(c.Param("storageID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid storage id"},
+				},
+			})
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"CSV file is required and must not exceed 10 MB"},
+				},
+			})
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(500, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EInternal,
+					Messages: []string{"Failed to read csv file"},
+				},
+			})
+			return
+		}
+
+		defer file.Close()
+
+		dryRun, _ := strconv.ParseBool(c.Query("dry_run"))
+
+		res, err := service.Import(c.Request.Context(), &basepaper.ImportBasePapersRequest{
+			StorageID: storageID,
+			DryRun:    dryRun,
+			File:      file,
+		})
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
 func getBasePaper(service basepaper.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		storageID := c.Param("basePaperID")
@@ -141,6 +223,56 @@ func searchInBufferArea(service basepaper.Service) gin.HandlerFunc {
 	}
 }
 
+func pickPlan(service basepaper.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		storageID, err := strconv.ParseInt(c.Param("storageID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid storage id"},
+				},
+			})
+			return
+		}
+
+		var req basepaper.PickPlanRequest
+
+		err = c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid query parameters"},
+				},
+			})
+			return
+		}
+
+		req.StorageID = storageID
+
+		res, err := service.PickPlan(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
 func searchInList(service basepaper.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var params basepaper.Params
@@ -191,6 +323,66 @@ func searchInList(service basepaper.Service) gin.HandlerFunc {
 	}
 }
 
+func exportBasePapers(service basepaper.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var params basepaper.Params
+		err := c.Bind(&params)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		storageID, err := strconv.ParseInt(c.Param("storageID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid storage id"},
+				},
+			})
+			return
+		}
+
+		params.StorageID = &storageID
+		format := c.DefaultQuery("format", basepaper.ExportCSV)
+		filename := fmt.Sprintf("storage-%d-%s.%s", storageID, time.Now().Format("20060102"), format)
+
+		c.Header("Content-Type", basepaper.ExportContentType(format))
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+		err = service.Export(c.Request.Context(), &basepaper.ExportBasePapersRequest{
+			Params:   params,
+			Format:   format,
+			Timezone: c.Query("tz"),
+		}, c.Writer)
+		if err != nil {
+			logrus.Error(err)
+			if c.Writer.Written() {
+				return
+			}
+
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+	}
+}
+
 func deliver(service basepaper.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		basePaperID, err := strconv.ParseInt(c.Param("basePaperID"), 10, 64)
@@ -241,6 +433,106 @@ func deliver(service basepaper.Service) gin.HandlerFunc {
 	}
 }
 
+func relocate(service basepaper.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		basePaperID, err := strconv.ParseInt(c.Param("basePaperID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid base paper id"},
+				},
+			})
+			return
+		}
+
+		var req basepaper.RelocateBasePaperRequest
+
+		err = c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		req.ID = basePaperID
+
+		res, err := service.Relocate(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func transfer(service basepaper.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		basePaperID, err := strconv.ParseInt(c.Param("basePaperID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid base paper id"},
+				},
+			})
+			return
+		}
+
+		var req basepaper.TransferBasePaperRequest
+
+		err = c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		req.ID = basePaperID
+
+		res, err := service.Transfer(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
 func moveToList(service basepaper.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		basePaperID, err := strconv.ParseInt(c.Param("basePaperID"), 10, 64)
@@ -291,6 +583,128 @@ func moveToList(service basepaper.Service) gin.HandlerFunc {
 	}
 }
 
+func adjust(service basepaper.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		basePaperID, err := strconv.ParseInt(c.Param("basePaperID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid base paper id"},
+				},
+			})
+			return
+		}
+
+		var req basepaper.AdjustBasePaperRequest
+
+		err = c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		req.ID = basePaperID
+
+		res, err := service.Adjust(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func returnBasePaper(service basepaper.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req basepaper.ReturnBasePaperRequest
+
+		err := c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		res, err := service.Return(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func reverseHistory(service basepaper.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req basepaper.ReverseHistoryRequest
+
+		err := c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		res, err := service.Reverse(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
 func deleteBasePaper(service basepaper.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		storageID := c.Param("basePaperID")
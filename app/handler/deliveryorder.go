@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/app/middleware"
+	"github.com/bagus2x/tjiwi/pkg/deliveryorder"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+func DeliveryOrder(r *gin.RouterGroup, service deliveryorder.Service, mw *middleware.Middleware) {
+	r.POST("", mw.AuthJWT(), mw.MustBeStorageMember(false, true), createDeliveryOrder(service))
+	r.GET("/storage/:storageID", mw.AuthJWT(), mw.MustBeStorageMember(false, true), getDeliveryOrders(service))
+	r.GET("/:deliveryOrderID", mw.AuthJWT(), mw.MustBeStorageMember(false, true), getDeliveryOrder(service))
+	r.PUT("/:deliveryOrderID", mw.AuthJWT(), mw.MustBeStorageMember(false, true), updateDeliveryOrder(service))
+	r.PUT("/:deliveryOrderID/pick", mw.AuthJWT(), mw.MustBeStorageMember(false, true), pickDeliveryOrder(service))
+	r.PUT("/:deliveryOrderID/ship", mw.AuthJWT(), mw.MustBeStorageMember(false, true), shipDeliveryOrder(service))
+	r.PUT("/:deliveryOrderID/cancel", mw.AuthJWT(), mw.MustBeStorageMember(false, true), cancelDeliveryOrder(service))
+}
+
+func createDeliveryOrder(service deliveryorder.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req deliveryorder.CreateDeliveryOrderRequest
+
+		err := c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		res, err := service.Create(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(201, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func getDeliveryOrders(service deliveryorder.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		storageID, err := strconv.ParseInt(c.Param("storageID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid storage id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.GetByStorageID(c.Request.Context(), storageID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func getDeliveryOrder(service deliveryorder.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		deliveryOrderID, err := strconv.ParseInt(c.Param("deliveryOrderID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid delivery order id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.GetByID(c.Request.Context(), deliveryOrderID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func updateDeliveryOrder(service deliveryorder.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		deliveryOrderID, err := strconv.ParseInt(c.Param("deliveryOrderID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid delivery order id"},
+				},
+			})
+			return
+		}
+
+		var req deliveryorder.UpdateDeliveryOrderRequest
+
+		err = c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		req.ID = deliveryOrderID
+
+		res, err := service.Update(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func pickDeliveryOrder(service deliveryorder.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		deliveryOrderID, err := strconv.ParseInt(c.Param("deliveryOrderID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid delivery order id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.Pick(c.Request.Context(), deliveryOrderID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func shipDeliveryOrder(service deliveryorder.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		deliveryOrderID, err := strconv.ParseInt(c.Param("deliveryOrderID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid delivery order id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.Ship(c.Request.Context(), deliveryOrderID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func cancelDeliveryOrder(service deliveryorder.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		deliveryOrderID, err := strconv.ParseInt(c.Param("deliveryOrderID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid delivery order id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.Cancel(c.Request.Context(), deliveryOrderID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
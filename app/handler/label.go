@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/app/middleware"
+	"github.com/bagus2x/tjiwi/pkg/label"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+func Label(r *gin.RouterGroup, service label.Service, mw *middleware.Middleware) {
+	r.GET("/basepaper/:basePaperID", mw.AuthJWT(), mw.MustBeStorageMember(false, true), printBasePaperLabel(service))
+	r.GET("/basepaper/:basePaperID/rolls", mw.AuthJWT(), mw.MustBeStorageMember(false, true), printRollLabels(service))
+	r.GET("/storage/:storageID/roll/:serial", mw.AuthJWT(), mw.MustBeStorageMember(false, true), printRollLabel(service))
+	r.GET("/storage/:storageID/locations", mw.AuthJWT(), mw.MustBeStorageMember(false, true), printLocationLabels(service))
+	r.GET("/location/:locationID", mw.AuthJWT(), mw.MustBeStorageMember(false, true), printLocationLabel(service))
+}
+
+func printBasePaperLabel(service label.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		basePaperID, err := strconv.ParseInt(c.Param("basePaperID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid base paper id"},
+				},
+			})
+			return
+		}
+
+		printLabels(c, fmt.Sprintf("basepaper-%d", basePaperID), func(format string, w io.Writer) error {
+			return service.PrintBasePaper(c.Request.Context(), basePaperID, format, w)
+		})
+	}
+}
+
+func printRollLabels(service label.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		basePaperID, err := strconv.ParseInt(c.Param("basePaperID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid base paper id"},
+				},
+			})
+			return
+		}
+
+		printLabels(c, fmt.Sprintf("basepaper-%d-rolls", basePaperID), func(format string, w io.Writer) error {
+			return service.PrintRolls(c.Request.Context(), basePaperID, format, w)
+		})
+	}
+}
+
+func printRollLabel(service label.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		storageID, err := strconv.ParseInt(c.Param("storageID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid storage id"},
+				},
+			})
+			return
+		}
+
+		serial := c.Param("serial")
+
+		printLabels(c, fmt.Sprintf("roll-%d-%s", storageID, serial), func(format string, w io.Writer) error {
+			return service.PrintRoll(c.Request.Context(), storageID, serial, format, w)
+		})
+	}
+}
+
+func printLocationLabels(service label.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		storageID, err := strconv.ParseInt(c.Param("storageID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid storage id"},
+				},
+			})
+			return
+		}
+
+		printLabels(c, fmt.Sprintf("storage-%d-locations", storageID), func(format string, w io.Writer) error {
+			return service.PrintLocations(c.Request.Context(), storageID, format, w)
+		})
+	}
+}
+
+func printLocationLabel(service label.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locationID, err := strconv.ParseInt(c.Param("locationID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid location id"},
+				},
+			})
+			return
+		}
+
+		printLabels(c, fmt.Sprintf("location-%d", locationID), func(format string, w io.Writer) error {
+			return service.PrintLocation(c.Request.Context(), locationID, format, w)
+		})
+	}
+}
+
+func printLabels(c *gin.Context, name string, print func(format string, w io.Writer) error) {
+	format := c.DefaultQuery("format", label.FormatPDF)
+
+	c.Header("Content-Type", label.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+"."+format))
+
+	err := print(format, c.Writer)
+	if err != nil {
+		logrus.Error(err)
+		if c.Writer.Written() {
+			return
+		}
+
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		c.JSON(app.Status(err), app.Failure{
+			Success: false,
+			Error: app.ErrorDetail{
+				Code:     app.ErrorCode(err),
+				Messages: app.ErrorMessage(err),
+			},
+		})
+	}
+}
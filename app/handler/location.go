@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/app/middleware"
+	"github.com/bagus2x/tjiwi/pkg/location"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+func Location(r *gin.RouterGroup, service location.Service, mw *middleware.Middleware) {
+	r.POST("", mw.AuthJWT(), mw.MustBeStorageMember(true, true), createLocation(service))
+	r.GET("/storage/:storageID", mw.AuthJWT(), mw.MustBeStorageMember(false, true), getLocations(service))
+	r.PUT("/:locationID/active", mw.AuthJWT(), mw.MustBeStorageMember(true, true), setLocationActive(service))
+	r.PUT("/:locationID/capacity", mw.AuthJWT(), mw.MustBeStorageMember(true, true), setLocationCapacity(service))
+	r.GET("/putaway/:basePaperID", mw.AuthJWT(), mw.MustBeStorageMember(false, true), suggestPutaway(service))
+}
+
+func createLocation(service location.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req location.CreateLocationRequest
+
+		err := c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		res, err := service.Create(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(201, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func getLocations(service location.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		storageID, err := strconv.ParseInt(c.Param("storageID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid storage id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.GetByStorageID(c.Request.Context(), storageID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func setLocationActive(service location.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locationID, err := strconv.ParseInt(c.Param("locationID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid location id"},
+				},
+			})
+			return
+		}
+
+		var req location.SetActiveRequest
+
+		err = c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		req.ID = locationID
+
+		res, err := service.SetActive(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func setLocationCapacity(service location.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locationID, err := strconv.ParseInt(c.Param("locationID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid location id"},
+				},
+			})
+			return
+		}
+
+		var req location.SetCapacityRequest
+
+		err = c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		req.ID = locationID
+
+		res, err := service.SetCapacity(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func suggestPutaway(service location.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		basePaperID, err := strconv.ParseInt(c.Param("basePaperID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid base paper id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.Suggest(c.Request.Context(), basePaperID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
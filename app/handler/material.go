@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/app/middleware"
+	"github.com/bagus2x/tjiwi/pkg/material"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+func Material(r *gin.RouterGroup, service material.Service, mw *middleware.Middleware) {
+	r.POST("", mw.AuthJWT(), mw.MustBeStorageMember(true, true), createMaterial(service))
+	r.GET("/storage/:storageID", mw.AuthJWT(), mw.MustBeStorageMember(false, true), getMaterials(service))
+	r.GET("/:materialID", mw.AuthJWT(), mw.MustBeStorageMember(false, true), getMaterial(service))
+	r.PUT("/:materialID", mw.AuthJWT(), mw.MustBeStorageMember(true, true), updateMaterial(service))
+}
+
+func createMaterial(service material.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req material.CreateMaterialRequest
+
+		err := c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		res, err := service.Create(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(201, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func getMaterials(service material.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		storageID, err := strconv.ParseInt(c.Param("storageID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid storage id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.GetByStorageID(c.Request.Context(), storageID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func getMaterial(service material.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		materialID, err := strconv.ParseInt(c.Param("materialID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid material id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.GetByID(c.Request.Context(), materialID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func updateMaterial(service material.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		materialID, err := strconv.ParseInt(c.Param("materialID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid material id"},
+				},
+			})
+			return
+		}
+
+		var req material.UpdateMaterialRequest
+
+		err = c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		req.ID = materialID
+
+		res, err := service.Update(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
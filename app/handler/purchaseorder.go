@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/app/middleware"
+	"github.com/bagus2x/tjiwi/pkg/purchaseorder"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+func PurchaseOrder(r *gin.RouterGroup, service purchaseorder.Service, mw *middleware.Middleware) {
+	r.POST("", mw.AuthJWT(), mw.MustBeStorageMember(false, true), createPurchaseOrder(service))
+	r.GET("/storage/:storageID", mw.AuthJWT(), mw.MustBeStorageMember(false, true), getPurchaseOrders(service))
+	r.GET("/:purchaseOrderID", mw.AuthJWT(), mw.MustBeStorageMember(false, true), getPurchaseOrder(service))
+	r.PUT("/:purchaseOrderID/receive", mw.AuthJWT(), mw.MustBeStorageMember(false, true), receivePurchaseOrder(service))
+	r.PUT("/:purchaseOrderID/close", mw.AuthJWT(), mw.MustBeStorageMember(true, true), closePurchaseOrder(service))
+	r.PUT("/:purchaseOrderID/cancel", mw.AuthJWT(), mw.MustBeStorageMember(true, true), cancelPurchaseOrder(service))
+}
+
+func createPurchaseOrder(service purchaseorder.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req purchaseorder.CreatePurchaseOrderRequest
+
+		err := c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		res, err := service.Create(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(201, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func getPurchaseOrders(service purchaseorder.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		storageID, err := strconv.ParseInt(c.Param("storageID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid storage id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.GetByStorageID(c.Request.Context(), storageID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func getPurchaseOrder(service purchaseorder.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		purchaseOrderID, err := strconv.ParseInt(c.Param("purchaseOrderID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid purchase order id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.GetByID(c.Request.Context(), purchaseOrderID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func receivePurchaseOrder(service purchaseorder.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		purchaseOrderID, err := strconv.ParseInt(c.Param("purchaseOrderID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid purchase order id"},
+				},
+			})
+			return
+		}
+
+		var req purchaseorder.ReceivePurchaseOrderRequest
+
+		err = c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		req.ID = purchaseOrderID
+
+		res, err := service.Receive(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func closePurchaseOrder(service purchaseorder.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		purchaseOrderID, err := strconv.ParseInt(c.Param("purchaseOrderID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid purchase order id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.Close(c.Request.Context(), purchaseOrderID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func cancelPurchaseOrder(service purchaseorder.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		purchaseOrderID, err := strconv.ParseInt(c.Param("purchaseOrderID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid purchase order id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.Cancel(c.Request.Context(), purchaseOrderID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/app/middleware"
+	"github.com/bagus2x/tjiwi/pkg/reservation"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+func Reservation(r *gin.RouterGroup, service reservation.Service, mw *middleware.Middleware) {
+	r.POST("", mw.AuthJWT(), mw.MustBeStorageMember(false, true), createReservation(service))
+	r.GET("/storage/:storageID", mw.AuthJWT(), mw.MustBeStorageMember(false, true), getReservations(service))
+	r.GET("/:reservationID", mw.AuthJWT(), mw.MustBeStorageMember(false, true), getReservation(service))
+	r.PUT("/:reservationID/release", mw.AuthJWT(), mw.MustBeStorageMember(false, true), releaseReservation(service))
+}
+
+func createReservation(service reservation.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req reservation.CreateReservationRequest
+
+		err := c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		res, err := service.Create(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(201, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func getReservations(service reservation.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		storageID, err := strconv.ParseInt(c.Param("storageID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid storage id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.GetByStorageID(c.Request.Context(), storageID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func getReservation(service reservation.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reservationID, err := strconv.ParseInt(c.Param("reservationID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid reservation id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.GetByID(c.Request.Context(), reservationID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func releaseReservation(service reservation.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reservationID, err := strconv.ParseInt(c.Param("reservationID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid reservation id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.Release(c.Request.Context(), reservationID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
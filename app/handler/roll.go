@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/app/middleware"
+	"github.com/bagus2x/tjiwi/pkg/roll"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+func Roll(r *gin.RouterGroup, service roll.Service, mw *middleware.Middleware) {
+	r.GET("/storage/:storageID/serial/:serial", mw.AuthJWT(), mw.MustBeStorageMember(false, true), getRollBySerial(service))
+	r.GET("/basepaper/:basePaperID", mw.AuthJWT(), mw.MustBeStorageMember(false, true), getRollsByBasePaper(service))
+}
+
+func getRollBySerial(service roll.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		storageID, err := strconv.ParseInt(c.Param("storageID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid storage id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.GetBySerial(c.Request.Context(), storageID, c.Param("serial"))
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func getRollsByBasePaper(service roll.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		basePaperID, err := strconv.ParseInt(c.Param("basePaperID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid base paper id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.GetByBasePaperID(c.Request.Context(), basePaperID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
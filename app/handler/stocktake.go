@@ -0,0 +1,309 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/app/middleware"
+	"github.com/bagus2x/tjiwi/pkg/stocktake"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+func StockTake(r *gin.RouterGroup, service stocktake.Service, mw *middleware.Middleware) {
+	r.POST("", mw.AuthJWT(), mw.MustBeStorageMember(true, true), openStockTake(service))
+	r.GET("/storage/:storageID", mw.AuthJWT(), mw.MustBeStorageMember(false, true), getStockTakes(service))
+	r.GET("/:stockTakeID", mw.AuthJWT(), mw.MustBeStorageMember(false, true), getStockTake(service))
+	r.PUT("/:stockTakeID/counts", mw.AuthJWT(), mw.MustBeStorageMember(false, true), submitCounts(service))
+	r.GET("/:stockTakeID/counts", mw.AuthJWT(), mw.MustBeStorageMember(false, true), getOwnCounts(service))
+	r.GET("/:stockTakeID/variances", mw.AuthJWT(), mw.MustBeStorageMember(true, true), getVariances(service))
+	r.PUT("/:stockTakeID/approve", mw.AuthJWT(), mw.MustBeStorageMember(true, true), approveStockTake(service))
+	r.PUT("/:stockTakeID/cancel", mw.AuthJWT(), mw.MustBeStorageMember(true, true), cancelStockTake(service))
+}
+
+func openStockTake(service stocktake.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req stocktake.OpenStockTakeRequest
+
+		err := c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		res, err := service.Open(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(201, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func getStockTakes(service stocktake.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		storageID, err := strconv.ParseInt(c.Param("storageID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid storage id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.GetByStorageID(c.Request.Context(), storageID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func getStockTake(service stocktake.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		stockTakeID, err := strconv.ParseInt(c.Param("stockTakeID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid stock take id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.GetByID(c.Request.Context(), stockTakeID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func submitCounts(service stocktake.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		stockTakeID, err := strconv.ParseInt(c.Param("stockTakeID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid stock take id"},
+				},
+			})
+			return
+		}
+
+		var req stocktake.SubmitCountsRequest
+
+		err = c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		req.StockTakeID = stockTakeID
+
+		res, err := service.SubmitCounts(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func getOwnCounts(service stocktake.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		stockTakeID, err := strconv.ParseInt(c.Param("stockTakeID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid stock take id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.GetOwnCounts(c.Request.Context(), stockTakeID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func getVariances(service stocktake.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		stockTakeID, err := strconv.ParseInt(c.Param("stockTakeID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid stock take id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.GetVariances(c.Request.Context(), stockTakeID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func approveStockTake(service stocktake.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		stockTakeID, err := strconv.ParseInt(c.Param("stockTakeID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid stock take id"},
+				},
+			})
+			return
+		}
+
+		res, err := service.Approve(c.Request.Context(), stockTakeID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func cancelStockTake(service stocktake.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		stockTakeID, err := strconv.ParseInt(c.Param("stockTakeID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid stock take id"},
+				},
+			})
+			return
+		}
+
+		err = service.Cancel(c.Request.Context(), stockTakeID)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.Status(204)
+	}
+}
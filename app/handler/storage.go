@@ -14,6 +14,8 @@ func Storage(r *gin.RouterGroup, service storage.Service, mw *middleware.Middlew
 	r.POST("", mw.AuthJWT(), createStorage(service))
 	r.GET("", mw.AuthJWT(), getStorages(service))
 	r.GET("/:storageID", mw.AuthJWT(), getStorage(service))
+	r.PUT("/:storageID/fifo", mw.AuthJWT(), mw.MustBeStorageMember(true, true), setEnforceFifo(service))
+	r.PUT("/:storageID/valuation-method", mw.AuthJWT(), mw.MustBeStorageMember(true, true), setValuationMethod(service))
 	r.DELETE("/:storageID", mw.AuthJWT(), deleteStorage(service))
 }
 
@@ -118,6 +120,106 @@ func getStorage(service storage.Service) gin.HandlerFunc {
 	}
 }
 
+func setEnforceFifo(service storage.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		storageID, err := strconv.ParseInt(c.Param("storageID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid storage id"},
+				},
+			})
+			return
+		}
+
+		var req storage.SetEnforceFifoRequest
+
+		err = c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		req.StorageID = storageID
+
+		res, err := service.SetEnforceFifo(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func setValuationMethod(service storage.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		storageID, err := strconv.ParseInt(c.Param("storageID"), 10, 64)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid storage id"},
+				},
+			})
+			return
+		}
+
+		var req storage.SetValuationMethodRequest
+
+		err = c.Bind(&req)
+		if err != nil {
+			c.JSON(400, app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.EBadRequest,
+					Messages: []string{"Invalid json format"},
+				},
+			})
+			return
+		}
+
+		req.StorageID = storageID
+
+		res, err := service.SetValuationMethod(c.Request.Context(), &req)
+		if err != nil {
+			logrus.Error(err)
+			c.JSON(app.Status(err), app.Failure{
+				Success: false,
+				Error: app.ErrorDetail{
+					Code:     app.ErrorCode(err),
+					Messages: app.ErrorMessage(err),
+				},
+			})
+			return
+		}
+
+		c.JSON(200, app.Success{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
 func deleteStorage(service storage.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		storageID := c.Param("storageID")
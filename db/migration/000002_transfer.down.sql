@@ -0,0 +1,13 @@
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM History WHERE status IN ('transfer_out', 'transfer_in')) THEN
+        RAISE EXCEPTION 'History contains transfer entries, they cannot be rolled back without losing the audit trail';
+    END IF;
+END $$;
+
+ALTER TABLE History DROP COLUMN reference_id;
+
+ALTER TYPE History_Status RENAME TO History_Status_Old;
+CREATE TYPE History_Status AS ENUM ('stored', 'moved','deleted', 'delivered');
+ALTER TABLE History ALTER COLUMN status TYPE History_Status USING status::TEXT::History_Status;
+DROP TYPE History_Status_Old;
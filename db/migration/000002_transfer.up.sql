@@ -0,0 +1,4 @@
+ALTER TYPE History_Status ADD VALUE 'transfer_out';
+ALTER TYPE History_Status ADD VALUE 'transfer_in';
+
+ALTER TABLE History ADD COLUMN reference_id INT NULL REFERENCES History(id);
@@ -0,0 +1,14 @@
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM History WHERE status IN ('relocated')) THEN
+        RAISE EXCEPTION 'History contains relocation entries, they cannot be rolled back without losing the audit trail';
+    END IF;
+END $$;
+
+ALTER TABLE History DROP COLUMN from_location;
+ALTER TABLE History DROP COLUMN to_location;
+
+ALTER TYPE History_Status RENAME TO History_Status_Old;
+CREATE TYPE History_Status AS ENUM ('stored', 'moved','deleted', 'delivered', 'transfer_out', 'transfer_in');
+ALTER TABLE History ALTER COLUMN status TYPE History_Status USING status::TEXT::History_Status;
+DROP TYPE History_Status_Old;
@@ -0,0 +1,8 @@
+ALTER TYPE History_Status ADD VALUE 'relocated';
+
+ALTER TABLE History ADD COLUMN from_location VARCHAR(10) NULL;
+ALTER TABLE History ADD COLUMN to_location VARCHAR(10) NULL;
+
+UPDATE History h SET to_location = bp.location FROM Base_Paper bp WHERE h.base_paper_id = bp.id AND h.status IN ('stored', 'transfer_in');
+UPDATE History h SET from_location = '', to_location = bp.location FROM Base_Paper bp WHERE h.base_paper_id = bp.id AND h.status = 'moved';
+UPDATE History h SET from_location = bp.location FROM Base_Paper bp WHERE h.base_paper_id = bp.id AND h.status IN ('delivered', 'deleted', 'transfer_out');
@@ -0,0 +1,11 @@
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM History WHERE status IN ('returned')) THEN
+        RAISE EXCEPTION 'History contains return entries, they cannot be rolled back without losing the audit trail';
+    END IF;
+END $$;
+
+ALTER TYPE History_Status RENAME TO History_Status_Old;
+CREATE TYPE History_Status AS ENUM ('stored', 'moved','deleted', 'delivered', 'transfer_out', 'transfer_in', 'relocated');
+ALTER TABLE History ALTER COLUMN status TYPE History_Status USING status::TEXT::History_Status;
+DROP TYPE History_Status_Old;
@@ -0,0 +1 @@
+ALTER TYPE History_Status ADD VALUE 'reversed';
@@ -0,0 +1,4 @@
+ALTER TYPE History_Status ADD VALUE 'adjusted';
+
+ALTER TABLE History ADD COLUMN reason VARCHAR(20) NULL;
+ALTER TABLE History ADD COLUMN note VARCHAR(512) NULL;
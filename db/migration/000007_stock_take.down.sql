@@ -0,0 +1,3 @@
+DROP TABLE Stock_Take_Count;
+DROP TABLE Stock_Take;
+DROP TYPE Stock_Take_Status;
@@ -0,0 +1,26 @@
+CREATE TYPE Stock_Take_Status AS ENUM ('open', 'approved', 'cancelled');
+
+CREATE TABLE Stock_Take (
+    id SERIAL PRIMARY KEY,
+    storage_id INT NOT NULL REFERENCES Storage(id),
+    member_id INT NOT NULL REFERENCES Profile(id),
+    locations VARCHAR(10)[] NOT NULL DEFAULT '{}',
+    status Stock_Take_Status NOT NULL,
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL
+);
+
+CREATE TABLE Stock_Take_Count (
+    id SERIAL PRIMARY KEY,
+    stock_take_id INT NOT NULL REFERENCES Stock_Take(id),
+    member_id INT NOT NULL REFERENCES Profile(id),
+    location VARCHAR(10) NOT NULL DEFAULT '',
+    gsm INT NOT NULL,
+    width INT NOT NULL,
+    io INT NOT NULL,
+    material_number INT NOT NULL,
+    quantity INT NOT NULL,
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL,
+    UNIQUE(stock_take_id, location, gsm, width, io, material_number)
+);
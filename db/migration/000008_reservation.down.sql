@@ -0,0 +1,2 @@
+DROP TABLE Reservation;
+DROP TYPE Reservation_Status;
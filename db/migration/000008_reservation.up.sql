@@ -0,0 +1,20 @@
+CREATE TYPE Reservation_Status AS ENUM ('active', 'released', 'fulfilled');
+
+CREATE TABLE Reservation (
+    id SERIAL PRIMARY KEY,
+    storage_id INT NOT NULL REFERENCES Storage(id),
+    base_paper_id INT NULL REFERENCES Base_Paper(id),
+    gsm INT NOT NULL,
+    width INT NOT NULL,
+    io INT NOT NULL,
+    material_number INT NOT NULL,
+    order_name VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL,
+    status Reservation_Status NOT NULL,
+    member_id INT NOT NULL REFERENCES Profile(id),
+    expires_at INT NOT NULL,
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL
+);
+
+CREATE INDEX reservation_spec_idx ON Reservation(storage_id, gsm, width, io, material_number) WHERE status = 'active';
@@ -0,0 +1,5 @@
+ALTER TABLE History DROP COLUMN delivery_order_id;
+
+DROP TABLE Delivery_Order_Line;
+DROP TABLE Delivery_Order;
+DROP TYPE Delivery_Order_Status;
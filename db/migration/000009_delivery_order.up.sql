@@ -0,0 +1,26 @@
+CREATE TYPE Delivery_Order_Status AS ENUM ('draft', 'picking', 'shipped', 'cancelled');
+
+CREATE TABLE Delivery_Order (
+    id SERIAL PRIMARY KEY,
+    storage_id INT NOT NULL REFERENCES Storage(id),
+    number VARCHAR(50) NOT NULL,
+    customer VARCHAR(255) NOT NULL,
+    status Delivery_Order_Status NOT NULL,
+    member_id INT NOT NULL REFERENCES Profile(id),
+    shipped_at INT NULL,
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL,
+    UNIQUE(storage_id, number)
+);
+
+CREATE TABLE Delivery_Order_Line (
+    id SERIAL PRIMARY KEY,
+    delivery_order_id INT NOT NULL REFERENCES Delivery_Order(id) ON DELETE CASCADE,
+    gsm INT NOT NULL,
+    width INT NOT NULL,
+    io INT NOT NULL,
+    material_number INT NOT NULL,
+    quantity INT NOT NULL
+);
+
+ALTER TABLE History ADD COLUMN delivery_order_id INT NULL REFERENCES Delivery_Order(id);
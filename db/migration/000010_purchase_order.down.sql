@@ -0,0 +1,5 @@
+ALTER TABLE History DROP COLUMN purchase_order_line_id;
+
+DROP TABLE Purchase_Order_Line;
+DROP TABLE Purchase_Order;
+DROP TYPE Purchase_Order_Status;
@@ -0,0 +1,27 @@
+CREATE TYPE Purchase_Order_Status AS ENUM ('open', 'partial', 'closed', 'cancelled');
+
+CREATE TABLE Purchase_Order (
+    id SERIAL PRIMARY KEY,
+    storage_id INT NOT NULL REFERENCES Storage(id),
+    number VARCHAR(50) NOT NULL,
+    supplier VARCHAR(255) NOT NULL,
+    expected_at INT NOT NULL,
+    status Purchase_Order_Status NOT NULL,
+    member_id INT NOT NULL REFERENCES Profile(id),
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL,
+    UNIQUE(storage_id, number)
+);
+
+CREATE TABLE Purchase_Order_Line (
+    id SERIAL PRIMARY KEY,
+    purchase_order_id INT NOT NULL REFERENCES Purchase_Order(id) ON DELETE CASCADE,
+    gsm INT NOT NULL,
+    width INT NOT NULL,
+    io INT NOT NULL,
+    material_number INT NOT NULL,
+    expected INT NOT NULL,
+    received INT NOT NULL DEFAULT 0
+);
+
+ALTER TABLE History ADD COLUMN purchase_order_line_id INT NULL REFERENCES Purchase_Order_Line(id);
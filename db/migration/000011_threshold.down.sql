@@ -0,0 +1,3 @@
+DROP TABLE Alert;
+DROP TABLE Threshold;
+DROP TYPE Alert_Status;
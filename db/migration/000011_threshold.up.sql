@@ -0,0 +1,30 @@
+CREATE TYPE Alert_Status AS ENUM ('open', 'resolved');
+
+CREATE TABLE Threshold (
+    id SERIAL PRIMARY KEY,
+    storage_id INT NOT NULL REFERENCES Storage(id),
+    gsm INT NOT NULL,
+    width INT NOT NULL,
+    material_number INT NOT NULL,
+    minimum INT NOT NULL,
+    member_id INT NOT NULL REFERENCES Profile(id),
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL,
+    UNIQUE(storage_id, gsm, width, material_number)
+);
+
+CREATE TABLE Alert (
+    id SERIAL PRIMARY KEY,
+    storage_id INT NOT NULL REFERENCES Storage(id),
+    threshold_id INT NOT NULL REFERENCES Threshold(id) ON DELETE CASCADE,
+    gsm INT NOT NULL,
+    width INT NOT NULL,
+    material_number INT NOT NULL,
+    minimum INT NOT NULL,
+    quantity INT NOT NULL,
+    status Alert_Status NOT NULL,
+    created_at INT NOT NULL,
+    resolved_at INT NULL
+);
+
+CREATE UNIQUE INDEX alert_open_idx ON Alert(threshold_id) WHERE status = 'open';
@@ -0,0 +1,16 @@
+CREATE TABLE Location (
+    id SERIAL PRIMARY KEY,
+    storage_id INT NOT NULL REFERENCES Storage(id),
+    zone VARCHAR(10) NOT NULL,
+    rack VARCHAR(10) NOT NULL DEFAULT '',
+    bin VARCHAR(10) NOT NULL DEFAULT '',
+    code VARCHAR(10) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL,
+    UNIQUE(storage_id, code)
+);
+
+INSERT INTO Location (storage_id, zone, code, created_at, updated_at)
+SELECT DISTINCT storage_id, location, location, EXTRACT(EPOCH FROM NOW())::INT, EXTRACT(EPOCH FROM NOW())::INT
+FROM Base_Paper WHERE location <> '' AND is_deleted = FALSE;
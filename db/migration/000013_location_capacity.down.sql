@@ -0,0 +1 @@
+ALTER TABLE Location DROP COLUMN capacity;
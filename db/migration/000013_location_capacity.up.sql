@@ -0,0 +1 @@
+ALTER TABLE Location ADD COLUMN capacity INT NULL;
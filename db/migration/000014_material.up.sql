@@ -0,0 +1,18 @@
+CREATE TABLE Material (
+    id SERIAL PRIMARY KEY,
+    storage_id INT NOT NULL REFERENCES Storage(id),
+    number INT NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    grade VARCHAR(50) NOT NULL DEFAULT '',
+    supplier VARCHAR(255) NOT NULL DEFAULT '',
+    default_gsm INT NULL,
+    default_io INT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL,
+    UNIQUE(storage_id, number)
+);
+
+INSERT INTO Material (storage_id, number, description, created_at, updated_at)
+SELECT DISTINCT storage_id, material_number, '', EXTRACT(EPOCH FROM NOW())::INT, EXTRACT(EPOCH FROM NOW())::INT
+FROM Base_Paper;
@@ -0,0 +1,2 @@
+ALTER TABLE History DROP COLUMN affected_weight;
+ALTER TABLE Base_Paper DROP COLUMN weight;
@@ -0,0 +1,2 @@
+ALTER TABLE Base_Paper ADD COLUMN weight NUMERIC(14, 3) NOT NULL DEFAULT 0;
+ALTER TABLE History ADD COLUMN affected_weight NUMERIC(14, 3) NOT NULL DEFAULT 0;
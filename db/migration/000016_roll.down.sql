@@ -0,0 +1,3 @@
+DROP TABLE History_Roll;
+DROP TABLE Roll;
+DROP TYPE Roll_Status;
@@ -0,0 +1,21 @@
+CREATE TYPE Roll_Status AS ENUM ('in_stock', 'delivered');
+
+CREATE TABLE Roll (
+    id SERIAL PRIMARY KEY,
+    storage_id INT NOT NULL REFERENCES Storage(id),
+    base_paper_id INT NOT NULL REFERENCES Base_Paper(id),
+    serial VARCHAR(64) NOT NULL,
+    weight NUMERIC(14, 3) NOT NULL DEFAULT 0,
+    status Roll_Status NOT NULL DEFAULT 'in_stock',
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL,
+    UNIQUE(storage_id, serial)
+);
+
+CREATE INDEX roll_base_paper_idx ON Roll(base_paper_id) WHERE status = 'in_stock';
+
+CREATE TABLE History_Roll (
+    history_id INT NOT NULL REFERENCES History(id),
+    roll_id INT NOT NULL REFERENCES Roll(id),
+    PRIMARY KEY (history_id, roll_id)
+);
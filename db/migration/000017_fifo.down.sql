@@ -0,0 +1 @@
+ALTER TABLE Storage DROP COLUMN enforce_fifo;
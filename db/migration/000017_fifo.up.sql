@@ -0,0 +1 @@
+ALTER TABLE Storage ADD COLUMN enforce_fifo BOOLEAN NOT NULL DEFAULT FALSE;
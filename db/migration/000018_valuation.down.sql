@@ -0,0 +1,3 @@
+DROP TABLE Cost_Layer;
+ALTER TABLE Storage DROP COLUMN valuation_method;
+DROP TYPE Valuation_Method;
@@ -0,0 +1,20 @@
+CREATE TYPE Valuation_Method AS ENUM ('fifo', 'average');
+
+ALTER TABLE Storage ADD COLUMN valuation_method Valuation_Method NOT NULL DEFAULT 'fifo';
+
+CREATE TABLE Cost_Layer (
+    id SERIAL PRIMARY KEY,
+    storage_id INT NOT NULL REFERENCES Storage(id),
+    gsm INT NOT NULL,
+    width INT NOT NULL,
+    io INT NOT NULL,
+    material_number INT NOT NULL,
+    history_id INT NULL REFERENCES History(id),
+    received INT NOT NULL,
+    remaining INT NOT NULL,
+    unit_cost NUMERIC(14, 2) NOT NULL,
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL
+);
+
+CREATE INDEX cost_layer_spec_idx ON Cost_Layer(storage_id, gsm, width, io, material_number, created_at);
@@ -0,0 +1 @@
+DROP INDEX history_storage_created_idx;
@@ -0,0 +1 @@
+CREATE INDEX history_storage_created_idx ON History(storage_id, created_at);
@@ -0,0 +1,18 @@
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT
+            1
+        FROM
+            Stock_Take_Count
+        GROUP BY
+            stock_take_id, location, gsm, width, io, material_number
+        HAVING
+            COUNT(*) > 1
+    ) THEN
+        RAISE EXCEPTION 'Stock_Take_Count contains counts from several members for the same spec, they cannot be rolled back without losing counts';
+    END IF;
+END $$;
+
+ALTER TABLE Stock_Take_Count DROP CONSTRAINT stock_take_count_member_spec_key;
+ALTER TABLE Stock_Take_Count ADD UNIQUE(stock_take_id, location, gsm, width, io, material_number);
@@ -0,0 +1,9 @@
+DO $$
+DECLARE
+    constraint_name TEXT;
+BEGIN
+    SELECT conname INTO constraint_name FROM pg_constraint WHERE conrelid = 'stock_take_count'::regclass AND contype = 'u';
+    EXECUTE format('ALTER TABLE Stock_Take_Count DROP CONSTRAINT %I', constraint_name);
+END $$;
+
+ALTER TABLE Stock_Take_Count ADD CONSTRAINT stock_take_count_member_spec_key UNIQUE(stock_take_id, member_id, location, gsm, width, io, material_number);
@@ -0,0 +1,9 @@
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM Cost_Layer WHERE unit_cost IS NULL) THEN
+        RAISE EXCEPTION 'Cost_Layer contains uncosted layers, they cannot be rolled back without losing stock';
+    END IF;
+END $$;
+
+ALTER TABLE Cost_Layer DROP COLUMN average_cost;
+ALTER TABLE Cost_Layer ALTER COLUMN unit_cost SET NOT NULL;
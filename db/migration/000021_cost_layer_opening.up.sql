@@ -0,0 +1,66 @@
+ALTER TABLE Cost_Layer ALTER COLUMN unit_cost DROP NOT NULL;
+ALTER TABLE Cost_Layer ADD COLUMN average_cost NUMERIC(14, 2) NULL;
+
+UPDATE Cost_Layer SET average_cost = unit_cost;
+
+UPDATE
+    Cost_Layer c
+SET
+    average_cost = ROUND(o.cost / o.remaining, 2)
+FROM (
+    SELECT DISTINCT ON (storage_id, gsm, width, io, material_number)
+        id, storage_id, gsm, width, io, material_number
+    FROM
+        Cost_Layer
+    ORDER BY
+        storage_id, gsm, width, io, material_number, created_at DESC, id DESC
+) l
+JOIN (
+    SELECT
+        storage_id, gsm, width, io, material_number, SUM(remaining * unit_cost) AS cost, SUM(remaining) AS remaining
+    FROM
+        Cost_Layer
+    WHERE
+        remaining > 0
+    GROUP BY
+        storage_id, gsm, width, io, material_number
+) o USING (storage_id, gsm, width, io, material_number)
+WHERE
+    c.id = l.id;
+
+INSERT INTO
+    Cost_Layer
+    (storage_id, gsm, width, io, material_number, history_id, received, remaining, unit_cost, average_cost, created_at, updated_at)
+SELECT
+    b.storage_id,
+    b.gsm,
+    b.width,
+    b.io,
+    b.material_number,
+    NULL,
+    b.quantity - COALESCE(c.remaining, 0),
+    b.quantity - COALESCE(c.remaining, 0),
+    NULL,
+    NULL,
+    LEAST(b.created_at, COALESCE(c.created_at, b.created_at)) - 1,
+    EXTRACT(EPOCH FROM NOW())::INT
+FROM (
+    SELECT
+        storage_id, gsm, width, io, material_number, SUM(quantity) AS quantity, MIN(created_at) AS created_at
+    FROM
+        Base_Paper
+    WHERE
+        is_deleted = FALSE
+    GROUP BY
+        storage_id, gsm, width, io, material_number
+) b
+LEFT JOIN (
+    SELECT
+        storage_id, gsm, width, io, material_number, SUM(remaining) AS remaining, MIN(created_at) AS created_at
+    FROM
+        Cost_Layer
+    GROUP BY
+        storage_id, gsm, width, io, material_number
+) c USING (storage_id, gsm, width, io, material_number)
+WHERE
+    b.quantity > COALESCE(c.remaining, 0);
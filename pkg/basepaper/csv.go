@@ -0,0 +1,125 @@
+package basepaper
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bagus2x/tjiwi/app"
+)
+
+var csvColumns = []string{"gsm", "width", "io", "material_number", "quantity"}
+
+type ImportRow struct {
+	Row     int
+	Request AddBasePaperRequest
+}
+
+func ParseCSV(r io.Reader, storageID int64) ([]*ImportRow, []ImportRowError, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, app.NewError(nil, app.EBadRequest, "CSV file is empty")
+	} else if err != nil {
+		return nil, nil, app.NewError(err, app.EBadRequest, "Invalid csv format")
+	}
+
+	indexes := make(map[string]int)
+	for i, column := range header {
+		indexes[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, column := range csvColumns {
+		if _, ok := indexes[column]; !ok {
+			return nil, nil, app.NewError(nil, app.EBadRequest, fmt.Sprintf("Column %s is required", column))
+		}
+	}
+
+	rows := make([]*ImportRow, 0)
+	rowErrors := make([]ImportRowError, 0)
+	line := 1
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		line++
+
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Row: line, Messages: []string{"Invalid csv row"}})
+			continue
+		}
+
+		values := make(map[string]int64)
+		messages := make([]string, 0)
+
+		for _, column := range csvColumns {
+			index := indexes[column]
+			if index >= len(record) {
+				messages = append(messages, fmt.Sprintf("%s is required", column))
+				continue
+			}
+
+			value, err := strconv.ParseInt(strings.TrimSpace(record[index]), 10, 64)
+			if err != nil {
+				messages = append(messages, fmt.Sprintf("%s must be a number", column))
+				continue
+			}
+
+			values[column] = value
+		}
+
+		var rollWeight float64
+		if index, ok := indexes["roll_weight"]; ok && index < len(record) && strings.TrimSpace(record[index]) != "" {
+			rollWeight, err = strconv.ParseFloat(strings.TrimSpace(record[index]), 64)
+			if err != nil {
+				messages = append(messages, "roll_weight must be a number")
+			}
+		}
+
+		var length int64
+		if index, ok := indexes["length"]; ok && index < len(record) && strings.TrimSpace(record[index]) != "" {
+			length, err = strconv.ParseInt(strings.TrimSpace(record[index]), 10, 64)
+			if err != nil {
+				messages = append(messages, "length must be a number")
+			}
+		}
+
+		var unitCost float64
+		if index, ok := indexes["unit_cost"]; ok && index < len(record) && strings.TrimSpace(record[index]) != "" {
+			unitCost, err = strconv.ParseFloat(strings.TrimSpace(record[index]), 64)
+			if err != nil {
+				messages = append(messages, "unit_cost must be a number")
+			}
+		}
+
+		if len(messages) != 0 {
+			rowErrors = append(rowErrors, ImportRowError{Row: line, Messages: messages})
+			continue
+		}
+
+		rows = append(rows, &ImportRow{
+			Row: line,
+			Request: AddBasePaperRequest{
+				StorageID:      storageID,
+				Gsm:            values["gsm"],
+				Width:          values["width"],
+				Io:             values["io"],
+				MaterialNumber: values["material_number"],
+				Quantity:       values["quantity"],
+				RollWeight:     rollWeight,
+				Length:         length,
+				UnitCost:       unitCost,
+			},
+		})
+	}
+
+	return rows, rowErrors, nil
+}
@@ -0,0 +1,64 @@
+package basepaper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCSV(t *testing.T) {
+	file := strings.NewReader("material_number,gsm,width,io,quantity\n4711,80,1200,3,10\n4711,abc,1200,3,10\n4712,70,900,3\n")
+
+	rows, rowErrors, err := ParseCSV(file, 1)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, 2, rows[0].Row)
+	assert.Equal(t, int64(4711), rows[0].Request.MaterialNumber)
+	assert.Equal(t, int64(80), rows[0].Request.Gsm)
+	assert.Equal(t, int64(1), rows[0].Request.StorageID)
+	assert.Len(t, rowErrors, 2)
+	assert.Equal(t, 3, rowErrors[0].Row)
+	assert.Equal(t, 4, rowErrors[1].Row)
+}
+
+func TestParseCSVMissingColumn(t *testing.T) {
+	_, _, err := ParseCSV(strings.NewReader("gsm,width,io,quantity\n80,1200,3,10\n"), 1)
+	assert.Error(t, err)
+}
+
+func TestParseCSVWeightColumns(t *testing.T) {
+	file := strings.NewReader("material_number,gsm,width,io,quantity,roll_weight,length\n4711,80,1200,3,2,950.5,\n4711,80,1200,3,2,,10000\n4711,80,1200,3,2,heavy,\n")
+
+	rows, rowErrors, err := ParseCSV(file, 1)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, 950.5, rows[0].Request.RollWeight)
+	assert.Equal(t, int64(10000), rows[1].Request.Length)
+	assert.Len(t, rowErrors, 1)
+	assert.Equal(t, 4, rowErrors[0].Row)
+}
+
+func TestParseCSVUnitCostColumn(t *testing.T) {
+	file := strings.NewReader("material_number,gsm,width,io,quantity,unit_cost\n4711,80,1200,3,2,1250.75\n4711,80,1200,3,2,\n4711,80,1200,3,2,free\n")
+
+	rows, rowErrors, err := ParseCSV(file, 1)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, 1250.75, rows[0].Request.UnitCost)
+	assert.Equal(t, 0.0, rows[1].Request.UnitCost)
+	assert.Len(t, rowErrors, 1)
+	assert.Equal(t, []string{"unit_cost must be a number"}, rowErrors[0].Messages)
+}
+
+func TestParseCSVNegativeQuantity(t *testing.T) {
+	file := strings.NewReader("material_number,gsm,width,io,quantity\n4711,80,1200,3,-5\n4711,80,1200,3,0\n")
+
+	rows, rowErrors, err := ParseCSV(file, 1)
+	assert.NoError(t, err)
+	assert.Len(t, rowErrors, 0)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, int64(-5), rows[0].Request.Quantity)
+	assert.Error(t, rows[0].Request.Validate())
+	assert.Error(t, rows[1].Request.Validate())
+}
@@ -0,0 +1,141 @@
+package basepaper
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	ExportCSV  = "csv"
+	ExportXLSX = "xlsx"
+)
+
+var exportHeader = []string{
+	"ID", "Area", "Location", "GSM", "Width", "IO", "Material Number", "Quantity", "Created At", "Updated At",
+}
+
+type ExportWriter interface {
+	Write(bp *model.BasePaper) error
+	Close() error
+}
+
+func NewExportWriter(format string, w io.Writer, loc *time.Location) (ExportWriter, error) {
+	switch format {
+	case ExportCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(exportHeader); err != nil {
+			return nil, err
+		}
+
+		return &csvExportWriter{writer: writer, loc: loc}, nil
+	case ExportXLSX:
+		file := excelize.NewFile()
+		stream, err := file.NewStreamWriter("Sheet1")
+		if err != nil {
+			return nil, err
+		}
+
+		header := make([]interface{}, 0)
+		for _, column := range exportHeader {
+			header = append(header, column)
+		}
+
+		if err := stream.SetRow("A1", header); err != nil {
+			return nil, err
+		}
+
+		return &xlsxExportWriter{file: file, stream: stream, w: w, loc: loc, row: 1}, nil
+	}
+
+	return nil, app.NewError(nil, app.EBadRequest, "Export format must be csv or xlsx")
+}
+
+func ExportContentType(format string) string {
+	if format == ExportXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	return "text/csv"
+}
+
+type csvExportWriter struct {
+	writer *csv.Writer
+	loc    *time.Location
+}
+
+func (e *csvExportWriter) Write(bp *model.BasePaper) error {
+	return e.writer.Write([]string{
+		strconv.FormatInt(bp.ID, 10),
+		exportArea(bp),
+		bp.Location,
+		strconv.FormatInt(bp.Gsm, 10),
+		strconv.FormatInt(bp.Width, 10),
+		strconv.FormatInt(bp.Io, 10),
+		strconv.FormatInt(bp.MaterialNumber, 10),
+		strconv.FormatInt(bp.Quantity, 10),
+		exportTime(bp.CreatedAt, e.loc),
+		exportTime(bp.UpdatedAt, e.loc),
+	})
+}
+
+func (e *csvExportWriter) Close() error {
+	e.writer.Flush()
+
+	return e.writer.Error()
+}
+
+type xlsxExportWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	w      io.Writer
+	loc    *time.Location
+	row    int
+}
+
+func (e *xlsxExportWriter) Write(bp *model.BasePaper) error {
+	e.row++
+
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+
+	return e.stream.SetRow(cell, []interface{}{
+		bp.ID,
+		exportArea(bp),
+		bp.Location,
+		bp.Gsm,
+		bp.Width,
+		bp.Io,
+		bp.MaterialNumber,
+		bp.Quantity,
+		exportTime(bp.CreatedAt, e.loc),
+		exportTime(bp.UpdatedAt, e.loc),
+	})
+}
+
+func (e *xlsxExportWriter) Close() error {
+	if err := e.stream.Flush(); err != nil {
+		return err
+	}
+
+	return e.file.Write(e.w)
+}
+
+func exportArea(bp *model.BasePaper) string {
+	if bp.Location == "" {
+		return "Buffer Area"
+	}
+
+	return "List"
+}
+
+func exportTime(unix int64, loc *time.Location) string {
+	return time.Unix(unix, 0).In(loc).Format("2006-01-02 15:04:05")
+}
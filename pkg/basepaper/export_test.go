@@ -0,0 +1,38 @@
+package basepaper
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVExportWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	writer, err := NewExportWriter(ExportCSV, &buf, time.UTC)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Write(&model.BasePaper{ID: 1, Gsm: 80, Width: 1200, Io: 3, MaterialNumber: 4711, Quantity: 5}))
+	assert.NoError(t, writer.Write(&model.BasePaper{ID: 2, Gsm: 80, Width: 1200, Io: 3, MaterialNumber: 4711, Quantity: 2, Location: "A1"}))
+	assert.NoError(t, writer.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[1], "1,Buffer Area,,80,1200,3,4711,5,"))
+	assert.True(t, strings.HasPrefix(lines[2], "2,List,A1,80,1200,3,4711,2,"))
+}
+
+func TestUnknownExportFormat(t *testing.T) {
+	_, err := NewExportWriter("pdf", &bytes.Buffer{}, time.UTC)
+	assert.Error(t, err)
+}
+
+func TestExportTimeInLocation(t *testing.T) {
+	unix := time.Date(2021, 3, 3, 20, 0, 0, 0, time.UTC).Unix()
+
+	assert.Equal(t, "2021-03-03 20:00:00", exportTime(unix, time.UTC))
+	assert.Equal(t, "2021-03-04 03:00:00", exportTime(unix, time.FixedZone("WIB", 7*3600)))
+}
@@ -0,0 +1,71 @@
+package basepaper
+
+import (
+	"sort"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+type Pick struct {
+	BasePaperID int64
+	Location    string
+	Quantity    int64
+	CreatedAt   int64
+}
+
+func EnteredBefore(a, b *model.BasePaper) bool {
+	if a.CreatedAt != b.CreatedAt {
+		return a.CreatedAt < b.CreatedAt
+	}
+
+	return a.ID < b.ID
+}
+
+func PickFIFO(basePapers []*model.BasePaper, quantity int64, available func(*model.BasePaper) int64) ([]*Pick, int64) {
+	sorted := make([]*model.BasePaper, len(basePapers))
+	copy(sorted, basePapers)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return EnteredBefore(sorted[i], sorted[j])
+	})
+
+	picks := make([]*Pick, 0)
+
+	for _, bp := range sorted {
+		if quantity <= 0 {
+			break
+		}
+
+		taken := available(bp)
+		if taken <= 0 {
+			continue
+		}
+		if taken > quantity {
+			taken = quantity
+		}
+
+		picks = append(picks, &Pick{
+			BasePaperID: bp.ID,
+			Location:    bp.Location,
+			Quantity:    taken,
+			CreatedAt:   bp.CreatedAt,
+		})
+		quantity -= taken
+	}
+
+	return picks, quantity
+}
+
+func OlderStock(basePapers []*model.BasePaper, bp *model.BasePaper, available func(*model.BasePaper) int64) *model.BasePaper {
+	var oldest *model.BasePaper
+
+	for _, other := range basePapers {
+		if other.ID == bp.ID || !EnteredBefore(other, bp) || available(other) <= 0 {
+			continue
+		}
+		if oldest == nil || EnteredBefore(other, oldest) {
+			oldest = other
+		}
+	}
+
+	return oldest
+}
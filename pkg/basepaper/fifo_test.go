@@ -0,0 +1,46 @@
+package basepaper
+
+import (
+	"testing"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func quantityOf(bp *model.BasePaper) int64 {
+	return bp.Quantity
+}
+
+func TestPickFIFO(t *testing.T) {
+	basePapers := []*model.BasePaper{
+		{ID: 3, Location: "C-1-1", Quantity: 5, CreatedAt: 300},
+		{ID: 1, Location: "A-1-1", Quantity: 2, CreatedAt: 100},
+		{ID: 2, Location: "B-1-1", Quantity: 0, CreatedAt: 200},
+		{ID: 4, Location: "D-1-1", Quantity: 4, CreatedAt: 100},
+	}
+
+	picks, short := PickFIFO(basePapers, 7, quantityOf)
+	assert.Equal(t, int64(0), short)
+	assert.Equal(t, []*Pick{
+		{BasePaperID: 1, Location: "A-1-1", Quantity: 2, CreatedAt: 100},
+		{BasePaperID: 4, Location: "D-1-1", Quantity: 4, CreatedAt: 100},
+		{BasePaperID: 3, Location: "C-1-1", Quantity: 1, CreatedAt: 300},
+	}, picks)
+	assert.Equal(t, int64(3), basePapers[0].ID)
+
+	picks, short = PickFIFO(basePapers, 20, quantityOf)
+	assert.Len(t, picks, 3)
+	assert.Equal(t, int64(9), short)
+}
+
+func TestOlderStock(t *testing.T) {
+	older := &model.BasePaper{ID: 1, Quantity: 2, CreatedAt: 100}
+	empty := &model.BasePaper{ID: 2, Quantity: 0, CreatedAt: 50}
+	bp := &model.BasePaper{ID: 3, Quantity: 4, CreatedAt: 200}
+	newer := &model.BasePaper{ID: 4, Quantity: 4, CreatedAt: 300}
+	basePapers := []*model.BasePaper{newer, bp, empty, older}
+
+	assert.Equal(t, older, OlderStock(basePapers, bp, quantityOf))
+	assert.Nil(t, OlderStock(basePapers, older, quantityOf))
+	assert.Nil(t, OlderStock(basePapers, bp, func(bp *model.BasePaper) int64 { return 0 }))
+}
@@ -10,7 +10,14 @@ type Repository interface {
 	Create(ctx context.Context, bp *model.BasePaper) error
 	Upsert(ctx context.Context, bp *model.BasePaper) error
 	FindByID(ctx context.Context, basePaperID int64) (*model.BasePaper, error)
+	FindBySpec(ctx context.Context, bp *model.BasePaper) (*model.BasePaper, error)
+	FindByLocations(ctx context.Context, storageID int64, locations []string) ([]*model.BasePaper, error)
+	FindInListBySpec(ctx context.Context, spec *model.BasePaper) ([]*model.BasePaper, error)
+	SumQuantityBySpec(ctx context.Context, spec *model.BasePaper) (int64, error)
+	SumQuantityByMaterial(ctx context.Context, spec *model.BasePaper) (int64, error)
+	SumQuantityByLocation(ctx context.Context, storageID int64, location string) (int64, error)
 	Filter(ctx context.Context, params *Params, locationEmpty bool) ([]*model.BasePaper, *Cursor, error)
+	Stream(ctx context.Context, params *Params, fn func(*model.BasePaper) error) error
 	Update(ctx context.Context, basePaper *model.BasePaper) error
 	SoftDelete(ctx context.Context, basePaperID int64) error
 	WithTransaction(ctx context.Context, fn func(context.Context) error) error
@@ -10,6 +10,7 @@ import (
 	"github.com/bagus2x/tjiwi/db"
 	"github.com/bagus2x/tjiwi/pkg/basepaper"
 	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
@@ -27,9 +28,9 @@ func (r *repository) Create(ctx context.Context, bp *model.BasePaper) error {
 	query := `
 			INSERT INTO
 				Base_Paper
-				(storage_id, gsm, width, io, material_number, quantity, location, created_at, updated_at)
+				(storage_id, gsm, width, io, material_number, quantity, weight, location, created_at, updated_at)
 			VALUES
-				($1, $2, $3, $4, $5, $6, $7, $8, $9)
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 			RETURNING
 				id
 	`
@@ -43,6 +44,7 @@ func (r *repository) Create(ctx context.Context, bp *model.BasePaper) error {
 		&bp.Io,
 		&bp.MaterialNumber,
 		&bp.Quantity,
+		&bp.Weight,
 		&bp.Location,
 		&bp.CreatedAt,
 		&bp.UpdatedAt,
@@ -57,17 +59,19 @@ func (r *repository) Upsert(ctx context.Context, bp *model.BasePaper) error {
 	query := `
 			INSERT INTO
 				Base_Paper
-				(storage_id, gsm, width, io, material_number, quantity, location, is_deleted, created_at, updated_at)
+				(storage_id, gsm, width, io, material_number, quantity, weight, location, is_deleted, created_at, updated_at)
 			VALUES
-				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 			ON CONFLICT
 				(storage_id, gsm, width, io, material_number, location)
 			DO UPDATE SET
 				quantity = Base_Paper.quantity + $6, 
-				updated_at = $9, 
+				weight = Base_Paper.weight + $7, 
+				created_at = CASE WHEN Base_Paper.quantity = 0 OR Base_Paper.is_deleted THEN EXCLUDED.created_at ELSE Base_Paper.created_at END,
+				updated_at = $11, 
 				is_deleted = FALSE
 			RETURNING
-				id, quantity, updated_at
+				id, quantity, weight, created_at, updated_at
 	`
 
 	err := tx.QueryRowContext(
@@ -79,6 +83,7 @@ func (r *repository) Upsert(ctx context.Context, bp *model.BasePaper) error {
 		&bp.Io,
 		&bp.MaterialNumber,
 		&bp.Quantity,
+		&bp.Weight,
 		&bp.Location,
 		&bp.IsDeleted,
 		&bp.CreatedAt,
@@ -86,6 +91,8 @@ func (r *repository) Upsert(ctx context.Context, bp *model.BasePaper) error {
 	).Scan(
 		&bp.ID,
 		&bp.Quantity,
+		&bp.Weight,
+		&bp.CreatedAt,
 		&bp.UpdatedAt,
 	)
 
@@ -97,7 +104,7 @@ func (r *repository) FindByID(ctx context.Context, basePaperID int64) (*model.Ba
 
 	query := `
 			SELECT
-				id, storage_id, gsm, width, io, material_number, quantity, location, created_at, updated_at
+				id, storage_id, gsm, width, io, material_number, quantity, weight, location, created_at, updated_at
 			FROM
 				Base_Paper
 			WHERE
@@ -115,6 +122,55 @@ func (r *repository) FindByID(ctx context.Context, basePaperID int64) (*model.Ba
 		&bp.Io,
 		&bp.MaterialNumber,
 		&bp.Quantity,
+		&bp.Weight,
+		&bp.Location,
+		&bp.CreatedAt,
+		&bp.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, app.NewError(err, app.ENotFound)
+		}
+		return nil, err
+	}
+
+	return &bp, nil
+}
+
+func (r *repository) FindBySpec(ctx context.Context, spec *model.BasePaper) (*model.BasePaper, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, storage_id, gsm, width, io, material_number, quantity, weight, location, created_at, updated_at
+			FROM
+				Base_Paper
+			WHERE
+				storage_id = $1 AND gsm = $2 AND width = $3 AND io = $4 AND material_number = $5 AND location = $6
+				AND is_deleted = FALSE
+			FOR UPDATE
+	`
+
+	var bp model.BasePaper
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		spec.Storage.ID,
+		spec.Gsm,
+		spec.Width,
+		spec.Io,
+		spec.MaterialNumber,
+		spec.Location,
+	).Scan(
+		&bp.ID,
+		&bp.Storage.ID,
+		&bp.Gsm,
+		&bp.Width,
+		&bp.Io,
+		&bp.MaterialNumber,
+		&bp.Quantity,
+		&bp.Weight,
 		&bp.Location,
 		&bp.CreatedAt,
 		&bp.UpdatedAt,
@@ -129,12 +185,188 @@ func (r *repository) FindByID(ctx context.Context, basePaperID int64) (*model.Ba
 	return &bp, nil
 }
 
+func (r *repository) FindByLocations(ctx context.Context, storageID int64, locations []string) ([]*model.BasePaper, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, storage_id, gsm, width, io, material_number, quantity, weight, location, created_at, updated_at
+			FROM
+				Base_Paper
+			WHERE
+				storage_id = $1 AND (CARDINALITY($2::VARCHAR[]) = 0 OR location = ANY($2)) AND is_deleted = FALSE
+				AND quantity > 0
+			ORDER BY
+				location ASC, id ASC
+			FOR UPDATE
+	`
+
+	rows, err := tx.QueryContext(ctx, query, storageID, pq.Array(locations))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	basePapers := make([]*model.BasePaper, 0)
+
+	for rows.Next() {
+		var bp model.BasePaper
+		err := rows.Scan(
+			&bp.ID,
+			&bp.Storage.ID,
+			&bp.Gsm,
+			&bp.Width,
+			&bp.Io,
+			&bp.MaterialNumber,
+			&bp.Quantity,
+			&bp.Weight,
+			&bp.Location,
+			&bp.CreatedAt,
+			&bp.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		basePapers = append(basePapers, &bp)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return basePapers, nil
+}
+
+func (r *repository) FindInListBySpec(ctx context.Context, spec *model.BasePaper) ([]*model.BasePaper, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, storage_id, gsm, width, io, material_number, quantity, weight, location, created_at, updated_at
+			FROM
+				Base_Paper
+			WHERE
+				storage_id = $1 AND gsm = $2 AND width = $3 AND io = $4 AND material_number = $5 AND location <> ''
+				AND is_deleted = FALSE AND quantity > 0
+			ORDER BY
+				created_at ASC, id ASC
+			FOR UPDATE
+	`
+
+	rows, err := tx.QueryContext(ctx, query, spec.Storage.ID, spec.Gsm, spec.Width, spec.Io, spec.MaterialNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	basePapers := make([]*model.BasePaper, 0)
+
+	for rows.Next() {
+		var bp model.BasePaper
+		err := rows.Scan(
+			&bp.ID,
+			&bp.Storage.ID,
+			&bp.Gsm,
+			&bp.Width,
+			&bp.Io,
+			&bp.MaterialNumber,
+			&bp.Quantity,
+			&bp.Weight,
+			&bp.Location,
+			&bp.CreatedAt,
+			&bp.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		basePapers = append(basePapers, &bp)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return basePapers, nil
+}
+
+func (r *repository) SumQuantityBySpec(ctx context.Context, spec *model.BasePaper) (int64, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				COALESCE(SUM(quantity), 0)
+			FROM (
+				SELECT
+					quantity
+				FROM
+					Base_Paper
+				WHERE
+					storage_id = $1 AND gsm = $2 AND width = $3 AND io = $4 AND material_number = $5 AND is_deleted = FALSE
+				FOR UPDATE
+			) AS spec
+	`
+
+	var sum int64
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		spec.Storage.ID,
+		spec.Gsm,
+		spec.Width,
+		spec.Io,
+		spec.MaterialNumber,
+	).Scan(&sum)
+
+	return sum, err
+}
+
+func (r *repository) SumQuantityByMaterial(ctx context.Context, spec *model.BasePaper) (int64, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				COALESCE(SUM(quantity), 0)
+			FROM
+				Base_Paper
+			WHERE
+				storage_id = $1 AND gsm = $2 AND width = $3 AND material_number = $4 AND is_deleted = FALSE
+	`
+
+	var sum int64
+
+	err := tx.QueryRowContext(ctx, query, spec.Storage.ID, spec.Gsm, spec.Width, spec.MaterialNumber).Scan(&sum)
+
+	return sum, err
+}
+
+func (r *repository) SumQuantityByLocation(ctx context.Context, storageID int64, location string) (int64, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				COALESCE(SUM(quantity), 0)
+			FROM
+				Base_Paper
+			WHERE
+				storage_id = $1 AND location = $2 AND is_deleted = FALSE
+	`
+
+	var sum int64
+
+	err := tx.QueryRowContext(ctx, query, storageID, location).Scan(&sum)
+
+	return sum, err
+}
+
 func (r *repository) Filter(ctx context.Context, params *basepaper.Params, isLocationEmpty bool) ([]*model.BasePaper, *basepaper.Cursor, error) {
 	tx := db.AllowTransaction(r.db, ctx)
 
 	query := `
 			SELECT
-				id, storage_id, gsm, width, io, material_number, quantity, location, created_at, updated_at
+				id, storage_id, gsm, width, io, material_number, quantity, weight, location, created_at, updated_at
 			From
 				Base_Paper
 	`
@@ -199,6 +431,7 @@ func (r *repository) Filter(ctx context.Context, params *basepaper.Params, isLoc
 			&bp.Io,
 			&bp.MaterialNumber,
 			&bp.Quantity,
+			&bp.Weight,
 			&bp.Location,
 			&bp.CreatedAt,
 			&bp.UpdatedAt,
@@ -220,6 +453,60 @@ func (r *repository) Filter(ctx context.Context, params *basepaper.Params, isLoc
 	return basePapers, &cursor, nil
 }
 
+func (r *repository) Stream(ctx context.Context, params *basepaper.Params, fn func(*model.BasePaper) error) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	where := "is_deleted = FALSE AND quantity > 0"
+	dynamicWhere, values := dynamicWhereClause(params, 1, " AND ")
+	if dynamicWhere != "" {
+		where = fmt.Sprintf("%s AND %s", dynamicWhere, where)
+	}
+
+	query := fmt.Sprintf(`
+			SELECT
+				id, storage_id, gsm, width, io, material_number, quantity, weight, location, created_at, updated_at
+			FROM
+				Base_Paper
+			WHERE
+				%s
+			ORDER BY
+				location ASC, id ASC
+	`, where)
+
+	rows, err := tx.QueryContext(ctx, query, values...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var bp model.BasePaper
+		err := rows.Scan(
+			&bp.ID,
+			&bp.Storage.ID,
+			&bp.Gsm,
+			&bp.Width,
+			&bp.Io,
+			&bp.MaterialNumber,
+			&bp.Quantity,
+			&bp.Weight,
+			&bp.Location,
+			&bp.CreatedAt,
+			&bp.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(&bp); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
 func (r *repository) Update(ctx context.Context, basePaper *model.BasePaper) error {
 	tx := db.AllowTransaction(r.db, ctx)
 
@@ -228,9 +515,9 @@ func (r *repository) Update(ctx context.Context, basePaper *model.BasePaper) err
 				Base_Paper
 			SET
 				gsm = $1, width = $2, io = $3, material_number = $4, quantity = $5,
-				location = $6, updated_at = $7
+				weight = $6, location = $7, updated_at = $8
 			WHERE
-				id = $8 AND is_deleted = FALSE
+				id = $9 AND is_deleted = FALSE
 	`
 
 	res, err := tx.ExecContext(
@@ -241,6 +528,7 @@ func (r *repository) Update(ctx context.Context, basePaper *model.BasePaper) err
 		&basePaper.Io,
 		&basePaper.MaterialNumber,
 		&basePaper.Quantity,
+		&basePaper.Weight,
 		&basePaper.Location,
 		&basePaper.UpdatedAt,
 		&basePaper.ID,
@@ -269,7 +557,8 @@ func (r *repository) SoftDelete(ctx context.Context, basePaperID int64) error {
 				Base_Paper
 			SET
 				is_deleted = TRUE,
-				quantity = 0
+				quantity = 0,
+				weight = 0
 			WHERE
 				id = $1
 	`
@@ -296,6 +585,10 @@ func (r *repository) SoftDelete(ctx context.Context, basePaperID int64) error {
 }
 
 func (r *repository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := ctx.Value(db.TransactionKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -1,13 +1,24 @@
 package basepaper
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 type Service interface {
 	StoreBasePaper(ctx context.Context, req *AddBasePaperRequest) (*AddBasePaperResponse, error)
+	Import(ctx context.Context, req *ImportBasePapersRequest) (*ImportBasePapersResponse, error)
 	GetByID(ctx context.Context, basePaperID int64) (*GetBasePaperResponse, error)
 	SearchInBufferArea(ctx context.Context, params *Params) (*GetBasePapersResponse, error)
 	SearchInList(ctx context.Context, params *Params) (*GetBasePapersResponse, error)
 	MoveToList(ctx context.Context, req *MoveToStorageRequest) (*MoveToStorageResponse, error)
+	Relocate(ctx context.Context, req *RelocateBasePaperRequest) (*RelocateBasePaperResponse, error)
+	Transfer(ctx context.Context, req *TransferBasePaperRequest) (*TransferBasePaperResponse, error)
+	PickPlan(ctx context.Context, req *PickPlanRequest) (*PickPlanResponse, error)
 	Deliver(ctx context.Context, req *DeliverBasePaperRequest) (*DeliverBasePaperResponse, error)
+	Export(ctx context.Context, req *ExportBasePapersRequest, w io.Writer) error
+	Adjust(ctx context.Context, req *AdjustBasePaperRequest) (*AdjustBasePaperResponse, error)
+	Return(ctx context.Context, req *ReturnBasePaperRequest) (*ReturnBasePaperResponse, error)
+	Reverse(ctx context.Context, req *ReverseHistoryRequest) (*ReverseHistoryResponse, error)
 	Delete(ctx context.Context, basePaperID int64) error
 }
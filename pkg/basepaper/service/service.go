@@ -2,52 +2,1476 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/db"
 	"github.com/bagus2x/tjiwi/pkg/basepaper"
 	"github.com/bagus2x/tjiwi/pkg/history"
+	"github.com/bagus2x/tjiwi/pkg/location"
+	"github.com/bagus2x/tjiwi/pkg/material"
 	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/purchaseorder"
+	"github.com/bagus2x/tjiwi/pkg/reservation"
+	"github.com/bagus2x/tjiwi/pkg/roll"
+	"github.com/bagus2x/tjiwi/pkg/storage"
+	stormemb "github.com/bagus2x/tjiwi/pkg/storagemember"
+	"github.com/bagus2x/tjiwi/pkg/threshold"
+	"github.com/bagus2x/tjiwi/pkg/valuation"
 	"github.com/bagus2x/tjiwi/utils"
 	"github.com/sirupsen/logrus"
 )
 
+var reversible = map[string]bool{
+	"stored":    true,
+	"moved":     true,
+	"relocated": true,
+	"delivered": true,
+	"deleted":   true,
+	"returned":  true,
+	"adjusted":  true,
+}
+
 type service struct {
-	basePaperRepo basepaper.Repository
-	historyRepo   history.Repository
+	basePaperRepo     basepaper.Repository
+	historyRepo       history.Repository
+	storMembRepo      stormemb.Repository
+	reservationRepo   reservation.Repository
+	thresholdService  threshold.Service
+	locationRepo      location.Repository
+	materialRepo      material.Repository
+	rollRepo          roll.Repository
+	storageRepo       storage.Repository
+	valuationService  valuation.Service
+	purchaseOrderRepo purchaseorder.Repository
 }
 
-func New(basePaperRepo basepaper.Repository, historyRepo history.Repository) basepaper.Service {
+func New(basePaperRepo basepaper.Repository, historyRepo history.Repository, storMembRepo stormemb.Repository, reservationRepo reservation.Repository, thresholdService threshold.Service, locationRepo location.Repository, materialRepo material.Repository, rollRepo roll.Repository, storageRepo storage.Repository, valuationService valuation.Service, purchaseOrderRepo purchaseorder.Repository) basepaper.Service {
 	return &service{
-		basePaperRepo: basePaperRepo,
-		historyRepo:   historyRepo,
+		basePaperRepo:     basePaperRepo,
+		historyRepo:       historyRepo,
+		storMembRepo:      storMembRepo,
+		reservationRepo:   reservationRepo,
+		thresholdService:  thresholdService,
+		locationRepo:      locationRepo,
+		materialRepo:      materialRepo,
+		rollRepo:          rollRepo,
+		storageRepo:       storageRepo,
+		valuationService:  valuationService,
+		purchaseOrderRepo: purchaseOrderRepo,
+	}
+}
+
+func (s *service) StoreBasePaper(ctx context.Context, req *basepaper.AddBasePaperRequest) (*basepaper.AddBasePaperResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, req.StorageID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	var bp *model.BasePaper
+
+	err = s.basePaperRepo.WithTransaction(ctx, func(c context.Context) error {
+		var err error
+		bp, err = s.store(c, req)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toAddBasePaperResponse(bp), nil
+}
+
+func (s *service) Import(ctx context.Context, req *basepaper.ImportBasePapersRequest) (*basepaper.ImportBasePapersResponse, error) {
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, req.StorageID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	rows, rowErrors, err := basepaper.ParseCSV(req.File, req.StorageID)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(rows) + len(rowErrors)
+	valid := make([]*basepaper.ImportRow, 0)
+	for _, row := range rows {
+		if err := row.Request.Validate(); err != nil {
+			rowErrors = append(rowErrors, basepaper.ImportRowError{Row: row.Row, Messages: app.ErrorMessage(err)})
+			continue
+		}
+		if err := s.mustBeActiveMaterial(ctx, row.Request.StorageID, row.Request.MaterialNumber); err != nil {
+			rowErrors = append(rowErrors, basepaper.ImportRowError{Row: row.Row, Messages: app.ErrorMessage(err)})
+			continue
+		}
+
+		valid = append(valid, row)
+	}
+
+	sort.Slice(rowErrors, func(i, j int) bool {
+		return rowErrors[i].Row < rowErrors[j].Row
+	})
+
+	res := basepaper.ImportBasePapersResponse{
+		DryRun:     req.DryRun,
+		Total:      total,
+		Valid:      len(valid),
+		Errors:     rowErrors,
+		BasePapers: make([]*basepaper.AddBasePaperResponse, 0),
+	}
+
+	if req.DryRun {
+		return &res, nil
+	}
+	if len(rowErrors) != 0 {
+		messages := make([]string, 0)
+		for _, rowErr := range rowErrors {
+			messages = append(messages, fmt.Sprintf("Row %d: %s", rowErr.Row, strings.Join(rowErr.Messages, ", ")))
+		}
+
+		return nil, app.NewError(nil, app.EBadRequest, messages...)
+	}
+	if len(valid) == 0 {
+		return nil, app.NewError(nil, app.EBadRequest, "CSV file has no rows")
+	}
+
+	err = s.basePaperRepo.WithTransaction(ctx, func(c context.Context) error {
+		for _, row := range valid {
+			bp, err := s.store(c, &row.Request)
+			if err != nil {
+				return err
+			}
+
+			res.BasePapers = append(res.BasePapers, toAddBasePaperResponse(bp))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *service) store(ctx context.Context, req *basepaper.AddBasePaperRequest) (*model.BasePaper, error) {
+	if err := s.mustBeActiveMaterial(ctx, req.StorageID, req.MaterialNumber); err != nil {
+		return nil, err
+	}
+
+	bp := model.BasePaper{
+		Storage:        model.Storage{ID: req.StorageID},
+		Gsm:            req.Gsm,
+		Width:          req.Width,
+		Io:             req.Io,
+		MaterialNumber: req.MaterialNumber,
+		Quantity:       req.Quantity,
+		Weight:         req.TotalWeight(),
+		CreatedAt:      time.Now().Unix(),
+		UpdatedAt:      time.Now().Unix(),
+	}
+
+	if len(req.Serials) != 0 {
+		existing, err := s.rollRepo.FindBySerials(ctx, req.StorageID, req.Serials)
+		if err != nil {
+			return nil, err
+		}
+		if len(existing) != 0 {
+			return nil, app.NewError(nil, app.Econflict, fmt.Sprintf("Roll %s already exist", existing[0].Serial))
+		}
+	}
+
+	if err := s.mustMatchTracking(ctx, &bp, len(req.Serials) != 0); err != nil {
+		return nil, err
+	}
+
+	err := s.basePaperRepo.Upsert(ctx, &bp)
+	if err != nil {
+		logrus.Error("error upsert")
+		return nil, app.NewError(err, app.EInternal, "Failed to save base paper")
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	h := model.History{
+		BasePaper:         model.BasePaper{ID: bp.ID},
+		Storage:           bp.Storage,
+		Member:            model.User{ID: memberID},
+		Status:            "stored",
+		Affected:          req.Quantity,
+		AffectedWeight:    req.TotalWeight(),
+		ToLocation:        db.NewNullString("", true),
+		PurchaseOrderLine: db.NewNullInt(req.PurchaseOrderLineID, req.PurchaseOrderLineID != 0),
+		CreatedAt:         bp.UpdatedAt,
+	}
+
+	err = s.historyRepo.Create(ctx, &h)
+	if err != nil {
+		logrus.Error("error create history")
+		return nil, err
+	}
+
+	rolls := make([]*model.Roll, 0, len(req.Serials))
+	for _, serial := range req.Serials {
+		rl := model.Roll{
+			Storage:   bp.Storage,
+			BasePaper: model.BasePaper{ID: bp.ID},
+			Serial:    serial,
+			Weight:    basepaper.Share(req.TotalWeight(), 1, req.Quantity),
+			Status:    "in_stock",
+			CreatedAt: bp.UpdatedAt,
+			UpdatedAt: bp.UpdatedAt,
+		}
+
+		err = s.rollRepo.Create(ctx, &rl)
+		if err != nil {
+			return nil, err
+		}
+
+		rolls = append(rolls, &rl)
+	}
+
+	err = s.rollRepo.AttachToHistory(ctx, h.ID, rolls)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.UnitCost > 0 {
+		err = s.valuationService.Receive(ctx, &bp, req.Quantity, req.UnitCost, h.ID)
+	} else {
+		err = s.valuationService.Restore(ctx, &bp, req.Quantity, h.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.thresholdService.Check(ctx, &bp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bp, nil
+}
+
+func (s *service) available(ctx context.Context, bp *model.BasePaper, reserved *model.Reservation) (int64, error) {
+	reservations, err := s.reservationRepo.FindActiveBySpec(ctx, bp, time.Now().Unix())
+	if err != nil {
+		return 0, err
+	}
+	if len(reservations) == 0 {
+		return bp.Quantity, nil
+	}
+
+	specTotal, err := s.basePaperRepo.SumQuantityBySpec(ctx, bp)
+	if err != nil {
+		return 0, err
+	}
+
+	excludeID := int64(0)
+	if reserved != nil {
+		excludeID = reserved.ID
+	}
+
+	return reservation.Available(bp, specTotal, reservations, excludeID), nil
+}
+
+func reservationMatches(r *model.Reservation, bp *model.BasePaper) bool {
+	if r.BasePaper.Valid {
+		return r.BasePaper.Int64 == bp.ID
+	}
+
+	return r.Storage.ID == bp.Storage.ID && r.Gsm == bp.Gsm && r.Width == bp.Width && r.Io == bp.Io &&
+		r.MaterialNumber == bp.MaterialNumber
+}
+
+func (s *service) carryReservations(ctx context.Context, reservations []*model.Reservation, basePaperID, now int64) error {
+	for _, r := range reservations {
+		r.BasePaper = db.NewNullInt(basePaperID, true)
+		r.UpdatedAt = now
+
+		err := s.reservationRepo.Update(ctx, r)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *service) rollsToMove(ctx context.Context, bp *model.BasePaper, serials []string, quantity int64) ([]*model.Roll, error) {
+	tracked, err := s.rollRepo.CountByBasePaperID(ctx, bp.ID)
+	if err != nil {
+		return nil, err
+	}
+	if tracked == 0 {
+		if len(serials) != 0 {
+			return nil, app.NewError(nil, app.EBadRequest, "Base paper is not tracked per roll")
+		}
+		return nil, nil
+	}
+
+	if len(serials) == 0 {
+		rolls, err := s.rollRepo.FindByBasePaperID(ctx, bp.ID)
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(rolls)) < quantity {
+			return nil, app.NewError(nil, app.EBadRequest, "Quantity exceeds the tracked rolls")
+		}
+
+		return rolls[:quantity], nil
+	}
+	if int64(len(serials)) != quantity {
+		return nil, app.NewError(nil, app.EBadRequest, "Number of serials must match the quantity")
+	}
+
+	rolls, err := s.rollRepo.FindBySerials(ctx, bp.Storage.ID, serials)
+	if err != nil {
+		return nil, err
+	}
+
+	return roll.Pick(rolls, serials, bp.ID)
+}
+
+func (s *service) moveRolls(ctx context.Context, rolls []*model.Roll, basePaperID int64, status string, now int64) error {
+	for _, rl := range rolls {
+		rl.BasePaper.ID = basePaperID
+		rl.Status = status
+		rl.UpdatedAt = now
+
+		err := s.rollRepo.Update(ctx, rl)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *service) mustMatchTracking(ctx context.Context, spec *model.BasePaper, tracked bool) error {
+	target, err := s.basePaperRepo.FindBySpec(ctx, spec)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	count, err := s.rollRepo.CountByBasePaperID(ctx, target.ID)
+	if err != nil {
+		return err
+	}
+	if tracked && count != target.Quantity {
+		return app.NewError(nil, app.EBadRequest, "Target base paper has untracked rolls")
+	}
+	if !tracked && count != 0 {
+		return app.NewError(nil, app.EBadRequest, "Target base paper is tracked per roll")
+	}
+
+	return nil
+}
+
+func (s *service) mustNotTrackRolls(ctx context.Context, bp *model.BasePaper) error {
+	count, err := s.rollRepo.CountByBasePaperID(ctx, bp.ID)
+	if err != nil {
+		return err
+	}
+	if count != 0 {
+		return app.NewError(nil, app.EBadRequest, "Base paper is tracked per roll")
+	}
+
+	return nil
+}
+
+func rollWeight(rolls []*model.Roll, fallback float64) float64 {
+	if weight, ok := roll.TotalWeight(rolls); ok {
+		return basepaper.RoundWeight(weight)
+	}
+
+	return fallback
+}
+
+func (s *service) mustBeActiveMaterial(ctx context.Context, storageID, number int64) error {
+	m, err := s.materialRepo.FindByNumber(ctx, storageID, number)
+	if app.ErrorCode(err) == app.ENotFound {
+		return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Material %d is not in the catalog", number))
+	} else if err != nil {
+		return err
+	}
+	if !m.IsActive {
+		return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Material %d is inactive", number))
+	}
+
+	return nil
+}
+
+func (s *service) materialDescriptions(ctx context.Context, basePapers []*model.BasePaper) (map[int64]map[int64]string, error) {
+	numbers := make(map[int64][]int64)
+	for _, bp := range basePapers {
+		numbers[bp.Storage.ID] = append(numbers[bp.Storage.ID], bp.MaterialNumber)
+	}
+
+	descriptions := make(map[int64]map[int64]string)
+	for storageID, storageNumbers := range numbers {
+		materials, err := s.materialRepo.FindByNumbers(ctx, storageID, storageNumbers)
+		if err != nil {
+			return nil, err
+		}
+
+		descriptions[storageID] = make(map[int64]string)
+		for _, m := range materials {
+			descriptions[storageID][m.Number] = m.Description
+		}
+	}
+
+	return descriptions, nil
+}
+
+func (s *service) mustFitLocation(ctx context.Context, storageID int64, code string, quantity int64) error {
+	loc, err := s.locationRepo.FindByCode(ctx, storageID, code)
+	if app.ErrorCode(err) == app.ENotFound {
+		return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Location %s is not registered", code))
+	} else if err != nil {
+		return err
+	}
+	if !loc.IsActive {
+		return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Location %s is inactive", code))
+	}
+	if !loc.Capacity.Valid {
+		return nil
+	}
+
+	occupied, err := s.basePaperRepo.SumQuantityByLocation(ctx, storageID, code)
+	if err != nil {
+		return err
+	}
+
+	free, _ := location.Free(loc, occupied)
+	if quantity > free {
+		return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Location %s only has room for %d more", code, free))
+	}
+
+	return nil
+}
+
+func toAddBasePaperResponse(bp *model.BasePaper) *basepaper.AddBasePaperResponse {
+	return &basepaper.AddBasePaperResponse{
+		ID:             bp.ID,
+		StorageID:      bp.Storage.ID,
+		Gsm:            bp.Gsm,
+		Width:          bp.Width,
+		Io:             bp.Io,
+		MaterialNumber: bp.MaterialNumber,
+		Quantity:       bp.Quantity,
+		Weight:         bp.Weight,
+		CreatedAt:      bp.CreatedAt,
+		UpdatedAt:      bp.UpdatedAt,
+	}
+}
+
+func (s *service) GetByID(ctx context.Context, basePaperID int64) (*basepaper.GetBasePaperResponse, error) {
+	bp, err := s.basePaperRepo.FindByID(ctx, basePaperID)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil, app.NewError(nil, app.ENotFound)
+	} else if err != nil {
+		return nil, err
+	}
+
+	descriptions, err := s.materialDescriptions(ctx, []*model.BasePaper{bp})
+	if err != nil {
+		return nil, err
+	}
+
+	res := basepaper.GetBasePaperResponse{
+		ID:                  bp.ID,
+		StorageID:           bp.Storage.ID,
+		Gsm:                 bp.Gsm,
+		Width:               bp.Width,
+		Io:                  bp.Io,
+		MaterialNumber:      bp.MaterialNumber,
+		MaterialDescription: descriptions[bp.Storage.ID][bp.MaterialNumber],
+		Location:            bp.Location,
+		Quantity:            bp.Quantity,
+		Weight:              bp.Weight,
+		CreatedAt:           bp.CreatedAt,
+		UpdatedAt:           bp.UpdatedAt,
+	}
+
+	return &res, nil
+}
+
+func (s *service) SearchInBufferArea(ctx context.Context, params *basepaper.Params) (*basepaper.GetBasePapersResponse, error) {
+	return s.search(ctx, params, true)
+}
+
+func (s *service) SearchInList(ctx context.Context, params *basepaper.Params) (*basepaper.GetBasePapersResponse, error) {
+	return s.search(ctx, params, false)
+}
+
+func (s *service) search(ctx context.Context, params *basepaper.Params, isLocationEmpty bool) (*basepaper.GetBasePapersResponse, error) {
+	basepapers, cursor, err := s.basePaperRepo.Filter(ctx, params, isLocationEmpty)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil, app.NewError(nil, app.ENotFound)
+	} else if err != nil {
+		return nil, err
+	}
+
+	available, err := s.availableQuantities(ctx, params, basepapers)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptions, err := s.materialDescriptions(ctx, basepapers)
+	if err != nil {
+		return nil, err
+	}
+
+	basepapersRes := make([]*basepaper.GetBasePaperResponse, 0)
+	for _, bp := range basepapers {
+		basepapersRes = append(basepapersRes, &basepaper.GetBasePaperResponse{
+			ID:                  bp.ID,
+			StorageID:           bp.Storage.ID,
+			Gsm:                 bp.Gsm,
+			Width:               bp.Width,
+			Io:                  bp.Io,
+			MaterialNumber:      bp.MaterialNumber,
+			MaterialDescription: descriptions[bp.Storage.ID][bp.MaterialNumber],
+			Location:            bp.Location,
+			Quantity:            bp.Quantity,
+			Weight:              bp.Weight,
+			Available:           available[bp.ID],
+			Reserved:            bp.Quantity - available[bp.ID],
+			CreatedAt:           bp.CreatedAt,
+			UpdatedAt:           bp.UpdatedAt,
+		})
+	}
+
+	res := basepaper.GetBasePapersResponse{
+		Cursor:     *cursor,
+		BasePapers: basepapersRes,
+	}
+
+	return &res, nil
+}
+
+func (s *service) availableQuantities(ctx context.Context, params *basepaper.Params, basepapers []*model.BasePaper) (map[int64]int64, error) {
+	available := make(map[int64]int64)
+	for _, bp := range basepapers {
+		available[bp.ID] = bp.Quantity
+	}
+	if params.StorageID == nil || len(basepapers) == 0 {
+		return available, nil
+	}
+
+	reservations, err := s.reservationRepo.FindActiveByStorageID(ctx, *params.StorageID, time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+	if len(reservations) == 0 {
+		return available, nil
+	}
+
+	type spec struct{ gsm, width, io, materialNumber int64 }
+
+	bySpec := make(map[spec][]*model.Reservation)
+	for _, r := range reservations {
+		key := spec{r.Gsm, r.Width, r.Io, r.MaterialNumber}
+		bySpec[key] = append(bySpec[key], r)
+	}
+
+	specTotals := make(map[spec]int64)
+	for _, bp := range basepapers {
+		key := spec{bp.Gsm, bp.Width, bp.Io, bp.MaterialNumber}
+		if len(bySpec[key]) == 0 {
+			continue
+		}
+
+		total, ok := specTotals[key]
+		if !ok {
+			total, err = s.basePaperRepo.SumQuantityBySpec(ctx, bp)
+			if err != nil {
+				return nil, err
+			}
+			specTotals[key] = total
+		}
+
+		available[bp.ID] = reservation.Available(bp, total, bySpec[key], 0)
+	}
+
+	return available, nil
+}
+
+func (s *service) MoveToList(ctx context.Context, req *basepaper.MoveToStorageRequest) (*basepaper.MoveToStorageResponse, error) {
+	var res basepaper.MoveToStorageResponse
+
+	err := s.basePaperRepo.WithTransaction(ctx, func(c context.Context) error {
+		bp, err := s.basePaperRepo.FindByID(c, req.ID)
+		if app.ErrorCode(err) == app.ENotFound {
+			return app.NewError(nil, app.ENotFound, "Base paper not found")
+		} else if err != nil {
+			return err
+		}
+		if bp.Location != "" || bp.Quantity == 0 {
+			return app.NewError(nil, app.ENotFound, "Base paper not found")
+		}
+		if bp.Quantity-req.Quantity < 0 {
+			return app.NewError(nil, app.EBadRequest, "Quantity exceeds the limit")
+		}
+
+		reservations, err := s.reservationRepo.FindActiveBySpec(c, bp, time.Now().Unix())
+		if err != nil {
+			return err
+		}
+
+		carried, ok := reservation.Carry(bp, reservations, req.Quantity)
+		if !ok {
+			return app.NewError(nil, app.EBadRequest, "Quantity splits a reservation on the base paper")
+		}
+
+		rolls, err := s.rollsToMove(c, bp, req.Serials, req.Quantity)
+		if err != nil {
+			return err
+		}
+
+		weight := rollWeight(rolls, basepaper.WeightOf(bp, req.Quantity))
+
+		bp.Quantity -= req.Quantity
+		bp.Weight = basepaper.RoundWeight(bp.Weight - weight)
+		bp.UpdatedAt = time.Now().Unix()
+
+		err = s.basePaperRepo.Update(c, bp)
+		if app.ErrorCode(err) == app.ENotFound {
+			return err
+		}
+
+		bp.Quantity = req.Quantity
+		bp.Weight = weight
+		bp.Location = strings.ToUpper(req.Location)
+		bp.CreatedAt = bp.UpdatedAt
+
+		err = s.mustFitLocation(c, bp.Storage.ID, bp.Location, bp.Quantity)
+		if err != nil {
+			return err
+		}
+
+		err = s.mustMatchTracking(c, bp, rolls != nil)
+		if err != nil {
+			return err
+		}
+
+		err = s.basePaperRepo.Upsert(c, bp)
+		if err != nil {
+			return err
+		}
+
+		err = s.moveRolls(c, rolls, bp.ID, "in_stock", bp.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		err = s.carryReservations(c, carried, bp.ID, bp.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		memberID, err := utils.GetUserIDFromCtx(c)
+		if err != nil {
+			return err
+		}
+
+		h := model.History{
+			BasePaper:      model.BasePaper{ID: bp.ID},
+			Storage:        bp.Storage,
+			Member:         model.User{ID: memberID},
+			Status:         "moved",
+			Affected:       req.Quantity,
+			AffectedWeight: weight,
+			FromLocation:   db.NewNullString("", true),
+			ToLocation:     db.NewNullString(bp.Location, true),
+			CreatedAt:      bp.UpdatedAt,
+		}
+
+		err = s.historyRepo.Create(c, &h)
+		if err != nil {
+			return err
+		}
+
+		err = s.rollRepo.AttachToHistory(c, h.ID, rolls)
+		if err != nil {
+			return err
+		}
+
+		res.ID = bp.ID
+		res.StorageID = bp.Storage.ID
+		res.Gsm = bp.Gsm
+		res.Width = bp.Width
+		res.Io = bp.Io
+		res.MaterialNumber = bp.MaterialNumber
+		res.Location = bp.Location
+		res.Quantity = bp.Quantity
+		res.Weight = bp.Weight
+		res.Serials = roll.Serials(rolls)
+		res.UpdatedAt = bp.UpdatedAt
+		res.CreatedAt = bp.CreatedAt
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *service) Relocate(ctx context.Context, req *basepaper.RelocateBasePaperRequest) (*basepaper.RelocateBasePaperResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var res basepaper.RelocateBasePaperResponse
+
+	err := s.basePaperRepo.WithTransaction(ctx, func(c context.Context) error {
+		bp, err := s.basePaperRepo.FindByID(c, req.ID)
+		if app.ErrorCode(err) == app.ENotFound {
+			return app.NewError(nil, app.ENotFound, "Base paper not found")
+		} else if err != nil {
+			return err
+		}
+		if bp.Location == "" || bp.Quantity == 0 {
+			return app.NewError(nil, app.ENotFound, "Base paper not found")
+		}
+
+		memberID, err := utils.GetUserIDFromCtx(c)
+		if err != nil {
+			return err
+		}
+
+		if err := stormemb.MustBeMember(c, s.storMembRepo, bp.Storage.ID, memberID, false); err != nil {
+			return err
+		}
+
+		location := strings.ToUpper(req.Location)
+		if bp.Location == location {
+			return app.NewError(nil, app.EBadRequest, "Base paper is already in the location")
+		}
+		if err := s.mustFitLocation(c, bp.Storage.ID, location, req.Quantity); err != nil {
+			return err
+		}
+		if bp.Quantity-req.Quantity < 0 {
+			return app.NewError(nil, app.EBadRequest, "Quantity exceeds the limit")
+		}
+
+		now := time.Now().Unix()
+
+		reservations, err := s.reservationRepo.FindActiveBySpec(c, bp, now)
+		if err != nil {
+			return err
+		}
+
+		carried, ok := reservation.Carry(bp, reservations, req.Quantity)
+		if !ok {
+			return app.NewError(nil, app.EBadRequest, "Quantity splits a reservation on the base paper")
+		}
+
+		rolls, err := s.rollsToMove(c, bp, req.Serials, req.Quantity)
+		if err != nil {
+			return err
+		}
+
+		sourceID := bp.ID
+		fromLocation := bp.Location
+		weight := rollWeight(rolls, basepaper.WeightOf(bp, req.Quantity))
+
+		bp.Quantity -= req.Quantity
+		bp.Weight = basepaper.RoundWeight(bp.Weight - weight)
+		bp.UpdatedAt = now
+
+		err = s.basePaperRepo.Update(c, bp)
+		if err != nil {
+			return err
+		}
+
+		bp.Quantity = req.Quantity
+		bp.Weight = weight
+		bp.Location = location
+		bp.CreatedAt = bp.UpdatedAt
+
+		err = s.mustMatchTracking(c, bp, rolls != nil)
+		if err != nil {
+			return err
+		}
+
+		err = s.basePaperRepo.Upsert(c, bp)
+		if err != nil {
+			return err
+		}
+
+		err = s.moveRolls(c, rolls, bp.ID, "in_stock", bp.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		err = s.carryReservations(c, carried, bp.ID, now)
+		if err != nil {
+			return err
+		}
+
+		h := model.History{
+			BasePaper:      model.BasePaper{ID: bp.ID},
+			Storage:        bp.Storage,
+			Member:         model.User{ID: memberID},
+			Status:         "relocated",
+			Affected:       req.Quantity,
+			AffectedWeight: weight,
+			FromLocation:   db.NewNullString(fromLocation, true),
+			ToLocation:     db.NewNullString(bp.Location, true),
+			CreatedAt:      bp.UpdatedAt,
+		}
+
+		err = s.historyRepo.Create(c, &h)
+		if err != nil {
+			return err
+		}
+
+		err = s.rollRepo.AttachToHistory(c, h.ID, rolls)
+		if err != nil {
+			return err
+		}
+
+		res = basepaper.RelocateBasePaperResponse{
+			ID:             bp.ID,
+			SourceID:       sourceID,
+			StorageID:      bp.Storage.ID,
+			Gsm:            bp.Gsm,
+			Width:          bp.Width,
+			Io:             bp.Io,
+			MaterialNumber: bp.MaterialNumber,
+			Quantity:       bp.Quantity,
+			Weight:         bp.Weight,
+			FromLocation:   fromLocation,
+			ToLocation:     bp.Location,
+			Serials:        roll.Serials(rolls),
+			CreatedAt:      bp.CreatedAt,
+			UpdatedAt:      bp.UpdatedAt,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *service) Transfer(ctx context.Context, req *basepaper.TransferBasePaperRequest) (*basepaper.TransferBasePaperResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var res basepaper.TransferBasePaperResponse
+
+	err := s.basePaperRepo.WithTransaction(ctx, func(c context.Context) error {
+		bp, err := s.basePaperRepo.FindByID(c, req.ID)
+		if app.ErrorCode(err) == app.ENotFound {
+			return app.NewError(nil, app.ENotFound, "Base paper not found")
+		} else if err != nil {
+			return err
+		}
+		if bp.Quantity == 0 {
+			return app.NewError(nil, app.ENotFound, "Base paper not found")
+		}
+		if bp.Storage.ID == req.TargetStorageID {
+			return app.NewError(nil, app.EBadRequest, "Target storage must be different from the source storage")
+		}
+		if bp.Quantity-req.Quantity < 0 {
+			return app.NewError(nil, app.EBadRequest, "Quantity exceeds the limit")
+		}
+
+		memberID, err := utils.GetUserIDFromCtx(c)
+		if err != nil {
+			return err
+		}
+
+		if err := stormemb.MustBeMember(c, s.storMembRepo, bp.Storage.ID, memberID, false); err != nil {
+			return err
+		}
+		if err := stormemb.MustBeMember(c, s.storMembRepo, req.TargetStorageID, memberID, false); err != nil {
+			return err
+		}
+		if err := s.mustNotTrackRolls(c, bp); err != nil {
+			return err
+		}
+
+		available, err := s.available(c, bp, nil)
+		if err != nil {
+			return err
+		}
+		if req.Quantity > available {
+			return app.NewError(nil, app.EBadRequest, "Quantity exceeds the unreserved quantity")
+		}
+
+		now := time.Now().Unix()
+		weight := basepaper.WeightOf(bp, req.Quantity)
+
+		bp.Quantity -= req.Quantity
+		bp.Weight = basepaper.RoundWeight(bp.Weight - weight)
+		bp.UpdatedAt = now
+
+		err = s.basePaperRepo.Update(c, bp)
+		if err != nil {
+			return err
+		}
+
+		transferOut := model.History{
+			BasePaper:      model.BasePaper{ID: bp.ID},
+			Storage:        bp.Storage,
+			Member:         model.User{ID: memberID},
+			Status:         "transfer_out",
+			Affected:       req.Quantity,
+			AffectedWeight: weight,
+			FromLocation:   db.NewNullString(bp.Location, true),
+			CreatedAt:      now,
+		}
+
+		err = s.historyRepo.Create(c, &transferOut)
+		if err != nil {
+			return err
+		}
+
+		err = s.mustBeActiveMaterial(c, req.TargetStorageID, bp.MaterialNumber)
+		if err != nil {
+			return err
+		}
+
+		target := model.BasePaper{
+			Storage:        model.Storage{ID: req.TargetStorageID},
+			Gsm:            bp.Gsm,
+			Width:          bp.Width,
+			Io:             bp.Io,
+			MaterialNumber: bp.MaterialNumber,
+			Quantity:       req.Quantity,
+			Weight:         weight,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+
+		err = s.mustMatchTracking(c, &target, false)
+		if err != nil {
+			return err
+		}
+
+		err = s.basePaperRepo.Upsert(c, &target)
+		if err != nil {
+			return err
+		}
+
+		transferIn := model.History{
+			BasePaper:      model.BasePaper{ID: target.ID},
+			Storage:        target.Storage,
+			Member:         model.User{ID: memberID},
+			Status:         "transfer_in",
+			Affected:       req.Quantity,
+			AffectedWeight: weight,
+			ToLocation:     db.NewNullString("", true),
+			Reference:      db.NewNullInt(transferOut.ID, true),
+			CreatedAt:      now,
+		}
+
+		err = s.historyRepo.Create(c, &transferIn)
+		if err != nil {
+			return err
+		}
+
+		issues, err := s.valuationService.Issue(c, bp, req.Quantity)
+		if err != nil {
+			return err
+		}
+
+		for _, issue := range issues {
+			if !issue.UnitCost.Valid {
+				continue
+			}
+
+			err = s.valuationService.Receive(c, &target, issue.Quantity, issue.UnitCost.Float64, transferIn.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = s.thresholdService.Check(c, bp)
+		if err != nil {
+			return err
+		}
+
+		err = s.thresholdService.Check(c, &target)
+		if err != nil {
+			return err
+		}
+
+		res = basepaper.TransferBasePaperResponse{
+			SourceID:           bp.ID,
+			TargetID:           target.ID,
+			SourceStorageID:    bp.Storage.ID,
+			TargetStorageID:    target.Storage.ID,
+			Quantity:           req.Quantity,
+			Weight:             weight,
+			TransferOutHistory: transferOut.ID,
+			TransferInHistory:  transferIn.ID,
+			CreatedAt:          now,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *service) PickPlan(ctx context.Context, req *basepaper.PickPlanRequest) (*basepaper.PickPlanResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, req.StorageID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	st, err := s.storageRepo.FindByID(ctx, req.StorageID)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil, app.NewError(nil, app.ENotFound, "Storage not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	spec := model.BasePaper{
+		Storage:        model.Storage{ID: req.StorageID},
+		Gsm:            req.Gsm,
+		Width:          req.Width,
+		Io:             req.Io,
+		MaterialNumber: req.MaterialNumber,
+	}
+
+	basePapers, err := s.basePaperRepo.FindInListBySpec(ctx, &spec)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations, err := s.reservationRepo.FindActiveBySpec(ctx, &spec, time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	pool := reservation.Pool(basePapers, reservations)
+
+	quantity := req.Quantity
+	if quantity > pool {
+		quantity = pool
+	}
+
+	picks, short := basepaper.PickFIFO(basePapers, quantity, func(bp *model.BasePaper) int64 {
+		return reservation.Unreserved(bp, reservations)
+	})
+
+	res := basepaper.PickPlanResponse{
+		StorageID:      req.StorageID,
+		Gsm:            req.Gsm,
+		Width:          req.Width,
+		Io:             req.Io,
+		MaterialNumber: req.MaterialNumber,
+		Quantity:       req.Quantity,
+		Picked:         quantity - short,
+		Short:          req.Quantity - quantity + short,
+		EnforceFifo:    st.EnforceFifo,
+		Picks:          make([]*basepaper.PickResponse, 0),
+	}
+
+	for _, pick := range picks {
+		res.Picks = append(res.Picks, &basepaper.PickResponse{
+			BasePaperID: pick.BasePaperID,
+			Location:    pick.Location,
+			Quantity:    pick.Quantity,
+			CreatedAt:   pick.CreatedAt,
+		})
+	}
+
+	return &res, nil
+}
+
+func (s *service) mustDeliverOldestFirst(ctx context.Context, bp *model.BasePaper, reserved *model.Reservation, override bool) error {
+	if reserved != nil && reserved.BasePaper.Valid {
+		return nil
+	}
+
+	st, err := s.storageRepo.FindByID(ctx, bp.Storage.ID)
+	if app.ErrorCode(err) == app.ENotFound {
+		return app.NewError(nil, app.ENotFound, "Storage not found")
+	} else if err != nil {
+		return err
+	}
+	if !st.EnforceFifo {
+		return nil
+	}
+
+	if override {
+		memberID, err := utils.GetUserIDFromCtx(ctx)
+		if err != nil {
+			return err
+		}
+
+		return stormemb.MustBeMember(ctx, s.storMembRepo, bp.Storage.ID, memberID, true)
+	}
+
+	basePapers, err := s.basePaperRepo.FindInListBySpec(ctx, bp)
+	if err != nil {
+		return err
+	}
+
+	reservations, err := s.reservationRepo.FindActiveBySpec(ctx, bp, time.Now().Unix())
+	if err != nil {
+		return err
+	}
+
+	older := basepaper.OlderStock(basePapers, bp, func(other *model.BasePaper) int64 {
+		return reservation.Unreserved(other, reservations)
+	})
+	if older != nil {
+		return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Older stock at location %s must be delivered first", older.Location))
+	}
+
+	return nil
+}
+
+func (s *service) Deliver(ctx context.Context, req *basepaper.DeliverBasePaperRequest) (*basepaper.DeliverBasePaperResponse, error) {
+	var res basepaper.DeliverBasePaperResponse
+	err := s.basePaperRepo.WithTransaction(ctx, func(c context.Context) error {
+		bp, err := s.basePaperRepo.FindByID(c, req.ID)
+		if app.ErrorCode(err) == app.ENotFound {
+			return app.NewError(nil, app.ENotFound, "Base paper not found")
+		} else if err != nil {
+			return err
+		}
+		if bp.Location == "" || bp.Quantity == 0 {
+			return app.NewError(nil, app.ENotFound, "Base paper not found")
+		}
+		if bp.Quantity-req.Quantity < 0 {
+			return app.NewError(nil, app.EBadRequest, "Quantity exceeds the limit")
+		}
+
+		now := time.Now().Unix()
+
+		var reserved *model.Reservation
+		if req.ReservationID != 0 {
+			reserved, err = s.reservationRepo.FindByID(c, req.ReservationID)
+			if app.ErrorCode(err) == app.ENotFound {
+				return app.NewError(nil, app.ENotFound, "Reservation not found")
+			} else if err != nil {
+				return err
+			}
+			if reservation.Status(reserved, now) != "active" {
+				return app.NewError(nil, app.EBadRequest, "Reservation is not active")
+			}
+			if !reservationMatches(reserved, bp) {
+				return app.NewError(nil, app.EBadRequest, "Reservation does not match the base paper")
+			}
+		}
+
+		available, err := s.available(c, bp, reserved)
+		if err != nil {
+			return err
+		}
+		if req.Quantity > available {
+			return app.NewError(nil, app.EBadRequest, "Quantity exceeds the unreserved quantity")
+		}
+
+		err = s.mustDeliverOldestFirst(c, bp, reserved, req.OverrideFifo)
+		if err != nil {
+			return err
+		}
+
+		rolls, err := s.rollsToMove(c, bp, req.Serials, req.Quantity)
+		if err != nil {
+			return err
+		}
+
+		weight := rollWeight(rolls, basepaper.WeightOf(bp, req.Quantity))
+
+		bp.Quantity -= req.Quantity
+		bp.Weight = basepaper.RoundWeight(bp.Weight - weight)
+		bp.UpdatedAt = now
+
+		err = s.basePaperRepo.Update(c, bp)
+		if err != nil {
+			return err
+		}
+
+		err = s.moveRolls(c, rolls, bp.ID, "delivered", now)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.valuationService.Issue(c, bp, req.Quantity)
+		if err != nil {
+			return err
+		}
+
+		if reserved != nil {
+			if req.Quantity >= reserved.Quantity {
+				reserved.Quantity = 0
+				reserved.Status = "fulfilled"
+			} else {
+				reserved.Quantity -= req.Quantity
+			}
+			reserved.UpdatedAt = now
+
+			err = s.reservationRepo.Update(c, reserved)
+			if err != nil {
+				return err
+			}
+		}
+
+		memberID, err := utils.GetUserIDFromCtx(c)
+		if err != nil {
+			return err
+		}
+
+		h := model.History{
+			BasePaper:      model.BasePaper{ID: bp.ID},
+			Storage:        bp.Storage,
+			Member:         model.User{ID: memberID},
+			Status:         "delivered",
+			Affected:       req.Quantity,
+			AffectedWeight: weight,
+			FromLocation:   db.NewNullString(bp.Location, true),
+			DeliveryOrder:  db.NewNullInt(req.DeliveryOrderID, req.DeliveryOrderID != 0),
+			CreatedAt:      now,
+		}
+
+		err = s.historyRepo.Create(c, &h)
+		if err != nil {
+			return err
+		}
+
+		err = s.rollRepo.AttachToHistory(c, h.ID, rolls)
+		if err != nil {
+			return err
+		}
+
+		err = s.thresholdService.Check(c, bp)
+		if err != nil {
+			return err
+		}
+
+		res = basepaper.DeliverBasePaperResponse{
+			ID:              req.ID,
+			Quantity:        req.Quantity,
+			Weight:          weight,
+			MemberID:        req.MemberID,
+			ReservationID:   req.ReservationID,
+			Serials:         roll.Serials(rolls),
+			DeliveryOrderID: req.DeliveryOrderID,
+		}
+
+		return nil
+	})
+
+	return &res, err
+}
+
+func (s *service) Export(ctx context.Context, req *basepaper.ExportBasePapersRequest, w io.Writer) error {
+	if req.Params.StorageID == nil {
+		return app.NewError(nil, app.EBadRequest, "Storage id is required")
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, *req.Params.StorageID, memberID, false); err != nil {
+		return err
+	}
+
+	loc := time.UTC
+	if req.Timezone != "" {
+		loc, err = time.LoadLocation(req.Timezone)
+		if err != nil {
+			return app.NewError(err, app.EBadRequest, "Invalid timezone")
+		}
+	}
+
+	writer, err := basepaper.NewExportWriter(req.Format, w, loc)
+	if err != nil {
+		return err
+	}
+
+	err = s.basePaperRepo.Stream(ctx, &req.Params, writer.Write)
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+func (s *service) Adjust(ctx context.Context, req *basepaper.AdjustBasePaperRequest) (*basepaper.AdjustBasePaperResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var res basepaper.AdjustBasePaperResponse
+
+	err := s.basePaperRepo.WithTransaction(ctx, func(c context.Context) error {
+		bp, err := s.basePaperRepo.FindByID(c, req.ID)
+		if app.ErrorCode(err) == app.ENotFound {
+			return app.NewError(nil, app.ENotFound, "Base paper not found")
+		} else if err != nil {
+			return err
+		}
+		if bp.Quantity+req.Delta < 0 {
+			return app.NewError(nil, app.EBadRequest, "Quantity exceeds the limit")
+		}
+
+		memberID, err := utils.GetUserIDFromCtx(c)
+		if err != nil {
+			return err
+		}
+
+		if err := stormemb.MustBeMember(c, s.storMembRepo, bp.Storage.ID, memberID, true); err != nil {
+			return err
+		}
+		if err := s.mustNotTrackRolls(c, bp); err != nil {
+			return err
+		}
+
+		if req.Delta < 0 {
+			available, err := s.available(c, bp, nil)
+			if err != nil {
+				return err
+			}
+			if -req.Delta > available {
+				return app.NewError(nil, app.EBadRequest, "Quantity exceeds the unreserved quantity")
+			}
+		}
+		if req.Delta > 0 && bp.Location != "" {
+			if err := s.mustFitLocation(c, bp.Storage.ID, bp.Location, req.Delta); err != nil {
+				return err
+			}
+		}
+
+		h := model.History{
+			BasePaper: model.BasePaper{ID: bp.ID},
+			Storage:   bp.Storage,
+			Member:    model.User{ID: memberID},
+			Status:    "adjusted",
+			Affected:  req.Delta,
+			Reason:    db.NewNullString(req.Reason, true),
+			Note:      db.NewNullString(req.Note, req.Note != ""),
+		}
+
+		if req.Delta < 0 {
+			h.Affected = -req.Delta
+			h.AffectedWeight = basepaper.WeightOf(bp, h.Affected)
+			h.FromLocation = db.NewNullString(bp.Location, true)
+			bp.Weight = basepaper.RoundWeight(bp.Weight - h.AffectedWeight)
+		} else {
+			h.AffectedWeight = basepaper.WeightOf(bp, h.Affected)
+			h.ToLocation = db.NewNullString(bp.Location, true)
+			bp.Weight = basepaper.RoundWeight(bp.Weight + h.AffectedWeight)
+		}
+
+		bp.Quantity += req.Delta
+		bp.UpdatedAt = time.Now().Unix()
+
+		err = s.basePaperRepo.Update(c, bp)
+		if err != nil {
+			return err
+		}
+
+		h.CreatedAt = bp.UpdatedAt
+
+		err = s.historyRepo.Create(c, &h)
+		if err != nil {
+			return err
+		}
+
+		if req.Delta < 0 {
+			_, err = s.valuationService.Issue(c, bp, -req.Delta)
+		} else {
+			err = s.valuationService.Restore(c, bp, req.Delta, h.ID)
+		}
+		if err != nil {
+			return err
+		}
+
+		err = s.thresholdService.Check(c, bp)
+		if err != nil {
+			return err
+		}
+
+		res = basepaper.AdjustBasePaperResponse{
+			ID:        bp.ID,
+			HistoryID: h.ID,
+			StorageID: bp.Storage.ID,
+			Location:  bp.Location,
+			Delta:     req.Delta,
+			Quantity:  bp.Quantity,
+			Weight:    bp.Weight,
+			Reason:    req.Reason,
+			Note:      req.Note,
+			UpdatedAt: bp.UpdatedAt,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	return &res, nil
 }
 
-func (s *service) StoreBasePaper(ctx context.Context, req *basepaper.AddBasePaperRequest) (*basepaper.AddBasePaperResponse, error) {
+func (s *service) Return(ctx context.Context, req *basepaper.ReturnBasePaperRequest) (*basepaper.ReturnBasePaperResponse, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
-	var bp model.BasePaper
+	var res basepaper.ReturnBasePaperResponse
 
 	err := s.basePaperRepo.WithTransaction(ctx, func(c context.Context) error {
-		bp = model.BasePaper{
-			Storage:        model.Storage{ID: req.StorageID},
-			Gsm:            req.Gsm,
-			Width:          req.Width,
-			Io:             req.Io,
-			MaterialNumber: req.MaterialNumber,
-			Quantity:       req.Quantity,
-			CreatedAt:      time.Now().Unix(),
-			UpdatedAt:      time.Now().Unix(),
+		delivered, err := s.historyRepo.FindByID(c, req.HistoryID)
+		if app.ErrorCode(err) == app.ENotFound {
+			return app.NewError(nil, app.ENotFound, "History not found")
+		} else if err != nil {
+			return err
 		}
-
-		err := s.basePaperRepo.Upsert(c, &bp)
-		if err != nil {
-			logrus.Error("error upsert")
-			return app.NewError(err, app.EInternal, "Failed to save base paper")
+		if delivered.Status != "delivered" {
+			return app.NewError(nil, app.EBadRequest, "Only delivered base papers can be returned")
 		}
 
 		memberID, err := utils.GetUserIDFromCtx(c)
@@ -55,165 +1479,281 @@ func (s *service) StoreBasePaper(ctx context.Context, req *basepaper.AddBasePape
 			return err
 		}
 
-		err = s.historyRepo.Create(c, &model.History{
-			BasePaper: model.BasePaper{ID: bp.ID},
-			Storage:   bp.Storage,
-			Member:    model.User{ID: memberID},
-			Status:    "stored",
-			Affected:  bp.Quantity,
-			CreatedAt: bp.UpdatedAt,
-		})
-		if err != nil {
-			logrus.Error("error create history")
+		if err := stormemb.MustBeMember(c, s.storMembRepo, delivered.Storage.ID, memberID, false); err != nil {
+			return err
 		}
 
-		return err
-
-	})
-	if err != nil {
-		return nil, err
-	}
+		reversed, err := s.historyRepo.SumAffectedByReference(c, delivered.ID, "reversed")
+		if err != nil {
+			return err
+		}
+		if reversed > 0 {
+			return app.NewError(nil, app.Econflict, "Delivery has already been reversed")
+		}
 
-	res := basepaper.AddBasePaperResponse{
-		ID:             bp.ID,
-		StorageID:      bp.Storage.ID,
-		Gsm:            bp.Gsm,
-		Width:          bp.Width,
-		Io:             bp.Io,
-		MaterialNumber: bp.MaterialNumber,
-		Quantity:       bp.Quantity,
-		CreatedAt:      bp.CreatedAt,
-		UpdatedAt:      bp.UpdatedAt,
-	}
+		returned, err := s.historyRepo.SumAffectedByReference(c, delivered.ID, "returned")
+		if err != nil {
+			return err
+		}
+		if returned+req.Quantity > delivered.Affected {
+			return app.NewError(nil, app.EBadRequest, "Quantity exceeds the delivered quantity")
+		}
 
-	return &res, nil
-}
+		now := time.Now().Unix()
+		weight := basepaper.Share(delivered.AffectedWeight, req.Quantity, delivered.Affected)
+		bp := model.BasePaper{
+			Storage:        delivered.Storage,
+			Gsm:            delivered.BasePaper.Gsm,
+			Width:          delivered.BasePaper.Width,
+			Io:             delivered.BasePaper.Io,
+			MaterialNumber: delivered.BasePaper.MaterialNumber,
+			Quantity:       req.Quantity,
+			Weight:         weight,
+			Location:       strings.ToUpper(req.Location),
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
 
-func (s *service) GetByID(ctx context.Context, basePaperID int64) (*basepaper.GetBasePaperResponse, error) {
-	bp, err := s.basePaperRepo.FindByID(ctx, basePaperID)
-	if app.ErrorCode(err) == app.ENotFound {
-		return nil, app.NewError(nil, app.ENotFound)
-	} else if err != nil {
-		return nil, err
-	}
+		if bp.Location != "" {
+			if err := s.mustFitLocation(c, bp.Storage.ID, bp.Location, bp.Quantity); err != nil {
+				return err
+			}
+		}
+		if err := s.mustMatchTracking(c, &bp, false); err != nil {
+			return err
+		}
 
-	res := basepaper.GetBasePaperResponse{
-		ID:             bp.ID,
-		StorageID:      bp.Storage.ID,
-		Gsm:            bp.Gsm,
-		Width:          bp.Width,
-		Io:             bp.Io,
-		MaterialNumber: bp.MaterialNumber,
-		Location:       bp.Location,
-		Quantity:       bp.Quantity,
-		CreatedAt:      bp.CreatedAt,
-		UpdatedAt:      bp.UpdatedAt,
-	}
+		err = s.basePaperRepo.Upsert(c, &bp)
+		if err != nil {
+			return err
+		}
 
-	return &res, nil
-}
+		h := model.History{
+			BasePaper:      model.BasePaper{ID: bp.ID},
+			Storage:        bp.Storage,
+			Member:         model.User{ID: memberID},
+			Status:         "returned",
+			Affected:       req.Quantity,
+			AffectedWeight: weight,
+			ToLocation:     db.NewNullString(bp.Location, true),
+			Reference:      db.NewNullInt(delivered.ID, true),
+			CreatedAt:      now,
+		}
 
-func (s *service) SearchInBufferArea(ctx context.Context, params *basepaper.Params) (*basepaper.GetBasePapersResponse, error) {
-	return s.search(ctx, params, true)
-}
+		err = s.historyRepo.Create(c, &h)
+		if err != nil {
+			return err
+		}
 
-func (s *service) SearchInList(ctx context.Context, params *basepaper.Params) (*basepaper.GetBasePapersResponse, error) {
-	return s.search(ctx, params, false)
-}
+		err = s.valuationService.Restore(c, &bp, req.Quantity, h.ID)
+		if err != nil {
+			return err
+		}
 
-func (s *service) search(ctx context.Context, params *basepaper.Params, isLocationEmpty bool) (*basepaper.GetBasePapersResponse, error) {
-	basepapers, cursor, err := s.basePaperRepo.Filter(ctx, params, isLocationEmpty)
-	if app.ErrorCode(err) == app.ENotFound {
-		return nil, app.NewError(nil, app.ENotFound)
-	} else if err != nil {
-		return nil, err
-	}
+		err = s.thresholdService.Check(c, &bp)
+		if err != nil {
+			return err
+		}
 
-	basepapersRes := make([]*basepaper.GetBasePaperResponse, 0)
-	for _, bp := range basepapers {
-		basepapersRes = append(basepapersRes, &basepaper.GetBasePaperResponse{
+		res = basepaper.ReturnBasePaperResponse{
 			ID:             bp.ID,
+			HistoryID:      h.ID,
+			ReferenceID:    delivered.ID,
 			StorageID:      bp.Storage.ID,
 			Gsm:            bp.Gsm,
 			Width:          bp.Width,
 			Io:             bp.Io,
 			MaterialNumber: bp.MaterialNumber,
+			Quantity:       req.Quantity,
+			Weight:         weight,
 			Location:       bp.Location,
-			Quantity:       bp.Quantity,
-			CreatedAt:      bp.CreatedAt,
-			UpdatedAt:      bp.UpdatedAt,
-		})
-	}
+			CreatedAt:      now,
+		}
 
-	res := basepaper.GetBasePapersResponse{
-		Cursor:     *cursor,
-		BasePapers: basepapersRes,
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &res, nil
 }
 
-func (s *service) MoveToList(ctx context.Context, req *basepaper.MoveToStorageRequest) (*basepaper.MoveToStorageResponse, error) {
-	var res basepaper.MoveToStorageResponse
+func (s *service) Reverse(ctx context.Context, req *basepaper.ReverseHistoryRequest) (*basepaper.ReverseHistoryResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var res basepaper.ReverseHistoryResponse
 
 	err := s.basePaperRepo.WithTransaction(ctx, func(c context.Context) error {
-		bp, err := s.basePaperRepo.FindByID(c, req.ID)
+		original, err := s.historyRepo.FindByID(c, req.HistoryID)
 		if app.ErrorCode(err) == app.ENotFound {
-			return app.NewError(nil, app.ENotFound, "Base paper not found")
+			return app.NewError(nil, app.ENotFound, "History not found")
 		} else if err != nil {
 			return err
 		}
-		if bp.Location != "" || bp.Quantity == 0 {
-			return app.NewError(nil, app.ENotFound, "Base paper not found")
+		if !reversible[original.Status] {
+			return app.NewError(nil, app.EBadRequest, fmt.Sprintf("History with status %s can not be reversed", original.Status))
 		}
-		if bp.Quantity-req.Quantity < 0 {
-			return app.NewError(nil, app.EBadRequest, "Quantity exceeds the limit")
+
+		memberID, err := utils.GetUserIDFromCtx(c)
+		if err != nil {
+			return err
 		}
 
-		bp.Quantity -= req.Quantity
-		bp.UpdatedAt = time.Now().Unix()
+		if err := stormemb.MustBeMember(c, s.storMembRepo, original.Storage.ID, memberID, true); err != nil {
+			return err
+		}
 
-		err = s.basePaperRepo.Update(c, bp)
-		if app.ErrorCode(err) == app.ENotFound {
+		reversed, err := s.historyRepo.SumAffectedByReference(c, original.ID, "reversed")
+		if err != nil {
 			return err
 		}
+		if reversed > 0 {
+			return app.NewError(nil, app.Econflict, "History has already been reversed")
+		}
 
-		bp.Quantity = req.Quantity
-		bp.Location = strings.ToUpper(req.Location)
-		bp.CreatedAt = bp.UpdatedAt
+		if original.Status == "delivered" {
+			if original.DeliveryOrder.Valid {
+				return app.NewError(nil, app.Econflict, "Delivery belongs to a shipped delivery order, return the stock instead")
+			}
 
-		err = s.basePaperRepo.Upsert(c, bp)
+			returned, err := s.historyRepo.SumAffectedByReference(c, original.ID, "returned")
+			if err != nil {
+				return err
+			}
+			if returned > 0 {
+				return app.NewError(nil, app.Econflict, "Delivery already has returns")
+			}
+		}
+
+		now := time.Now().Unix()
+		spec := model.BasePaper{
+			Storage:        original.Storage,
+			Gsm:            original.BasePaper.Gsm,
+			Width:          original.BasePaper.Width,
+			Io:             original.BasePaper.Io,
+			MaterialNumber: original.BasePaper.MaterialNumber,
+		}
+
+		basePaperID := original.BasePaper.ID
+		carried := make([]*model.Reservation, 0)
+
+		if original.ToLocation.Valid {
+			spec.Location = original.ToLocation.String
+
+			bp, err := s.basePaperRepo.FindBySpec(c, &spec)
+			if app.ErrorCode(err) == app.ENotFound {
+				return app.NewError(nil, app.Econflict, "Stock has already been consumed by later operations")
+			} else if err != nil {
+				return err
+			}
+			if bp.Quantity < original.Affected {
+				return app.NewError(nil, app.Econflict, "Stock has already been consumed by later operations")
+			}
+			if err := s.mustNotTrackRolls(c, bp); err != nil {
+				return err
+			}
+
+			carried, err = s.reverseReservations(c, bp, original)
+			if err != nil {
+				return err
+			}
+
+			bp.Quantity -= original.Affected
+			bp.Weight = basepaper.RoundWeight(bp.Weight - original.AffectedWeight)
+			bp.UpdatedAt = now
+
+			err = s.basePaperRepo.Update(c, bp)
+			if err != nil {
+				return err
+			}
+
+			basePaperID = bp.ID
+		}
+
+		if original.FromLocation.Valid {
+			bp := spec
+			bp.Location = original.FromLocation.String
+			bp.Quantity = original.Affected
+			bp.Weight = original.AffectedWeight
+			bp.CreatedAt = now
+			bp.UpdatedAt = now
+
+			if bp.Location != "" {
+				if err := s.mustFitLocation(c, bp.Storage.ID, bp.Location, bp.Quantity); err != nil {
+					return err
+				}
+			}
+
+			err = s.mustMatchTracking(c, &bp, false)
+			if err != nil {
+				return err
+			}
+
+			err = s.basePaperRepo.Upsert(c, &bp)
+			if err != nil {
+				return err
+			}
+
+			err = s.carryReservations(c, carried, bp.ID, now)
+			if err != nil {
+				return err
+			}
+
+			basePaperID = bp.ID
+		}
+
+		h := model.History{
+			BasePaper:      model.BasePaper{ID: basePaperID},
+			Storage:        original.Storage,
+			Member:         model.User{ID: memberID},
+			Status:         "reversed",
+			Affected:       original.Affected,
+			AffectedWeight: original.AffectedWeight,
+			FromLocation:   original.ToLocation,
+			ToLocation:     original.FromLocation,
+			Reference:      db.NewNullInt(original.ID, true),
+			CreatedAt:      now,
+		}
+
+		err = s.historyRepo.Create(c, &h)
 		if err != nil {
 			return err
 		}
 
-		memberID, err := utils.GetUserIDFromCtx(c)
+		if original.Status == "stored" && original.PurchaseOrderLine.Valid {
+			err = s.unreceive(c, original.PurchaseOrderLine.Int64, original.Affected, now)
+			if err != nil {
+				return err
+			}
+		}
+
+		if original.ToLocation.Valid && !original.FromLocation.Valid {
+			_, err = s.valuationService.IssueReceipt(c, &spec, original.Affected, original.ID)
+		} else if original.FromLocation.Valid && !original.ToLocation.Valid {
+			err = s.valuationService.Restore(c, &spec, original.Affected, h.ID)
+		}
 		if err != nil {
 			return err
 		}
 
-		err = s.historyRepo.Create(c, &model.History{
-			BasePaper: model.BasePaper{ID: bp.ID},
-			Storage:   bp.Storage,
-			Member:    model.User{ID: memberID},
-			Status:    "moved",
-			Affected:  req.Quantity,
-			CreatedAt: bp.UpdatedAt,
-		})
+		err = s.thresholdService.Check(c, &spec)
 		if err != nil {
 			return err
 		}
 
-		res.ID = bp.ID
-		res.StorageID = bp.Storage.ID
-		res.Gsm = bp.Gsm
-		res.Width = bp.Width
-		res.Io = bp.Io
-		res.MaterialNumber = bp.MaterialNumber
-		res.Location = bp.Location
-		res.Quantity = bp.Quantity
-		res.UpdatedAt = bp.UpdatedAt
-		res.CreatedAt = bp.CreatedAt
+		res = basepaper.ReverseHistoryResponse{
+			ID:          h.ID,
+			ReferenceID: original.ID,
+			BasePaperID: basePaperID,
+			StorageID:   original.Storage.ID,
+			Status:      original.Status,
+			Affected:    original.Affected,
+			Weight:      original.AffectedWeight,
+			CreatedAt:   now,
+		}
 
 		return nil
 	})
@@ -224,52 +1764,76 @@ func (s *service) MoveToList(ctx context.Context, req *basepaper.MoveToStorageRe
 	return &res, nil
 }
 
-func (s *service) Deliver(ctx context.Context, req *basepaper.DeliverBasePaperRequest) (*basepaper.DeliverBasePaperResponse, error) {
-	var res basepaper.DeliverBasePaperResponse
-	err := s.basePaperRepo.WithTransaction(ctx, func(c context.Context) error {
-		bp, err := s.basePaperRepo.FindByID(c, req.ID)
-		if app.ErrorCode(err) == app.ENotFound {
-			return app.NewError(nil, app.ENotFound, "Base paper not found")
-		} else if err != nil {
-			return err
-		}
-		if bp.Location == "" || bp.Quantity == 0 {
-			return app.NewError(nil, app.ENotFound, "Base paper not found")
+func (s *service) reverseReservations(ctx context.Context, bp *model.BasePaper, original *model.History) ([]*model.Reservation, error) {
+	if !original.FromLocation.Valid {
+		available, err := s.available(ctx, bp, nil)
+		if err != nil {
+			return nil, err
 		}
-		if bp.Quantity-req.Quantity < 0 {
-			return app.NewError(nil, app.EBadRequest, "Quantity exceeds the limit")
+		if original.Affected > available {
+			return nil, app.NewError(nil, app.EBadRequest, "Quantity exceeds the unreserved quantity")
 		}
 
-		bp.Quantity -= req.Quantity
+		return nil, nil
+	}
 
-		err = s.basePaperRepo.Update(c, bp)
-		if err != nil {
-			return err
-		}
+	reservations, err := s.reservationRepo.FindActiveBySpec(ctx, bp, time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
 
-		memberID, err := utils.GetUserIDFromCtx(c)
-		if err != nil {
-			return err
+	if original.FromLocation.String == "" {
+		if original.Affected > reservation.Unreserved(bp, reservations) {
+			return nil, app.NewError(nil, app.EBadRequest, "Quantity exceeds the unreserved quantity")
 		}
 
-		err = s.historyRepo.Create(c, &model.History{
-			BasePaper: model.BasePaper{ID: bp.ID},
-			Storage:   bp.Storage,
-			Member:    model.User{ID: memberID},
-			Status:    "delivered",
-			Affected:  req.Quantity,
-			CreatedAt: bp.CreatedAt,
-		})
-		if err != nil {
-			return err
+		return nil, nil
+	}
+
+	carried, ok := reservation.Carry(bp, reservations, original.Affected)
+	if !ok {
+		return nil, app.NewError(nil, app.EBadRequest, "Quantity splits a reservation on the base paper")
+	}
+
+	return carried, nil
+}
+
+func (s *service) unreceive(ctx context.Context, lineID, quantity, now int64) error {
+	line, err := s.purchaseOrderRepo.FindLineByID(ctx, lineID)
+	if err != nil {
+		return err
+	}
+
+	order, err := s.purchaseOrderRepo.FindByID(ctx, line.PurchaseOrder.ID)
+	if err != nil {
+		return err
+	}
+
+	completed := purchaseorder.OrderStatus(order.Lines) == "closed"
+
+	for _, l := range order.Lines {
+		if l.ID == line.ID {
+			l.Received -= quantity
+			line = l
 		}
+	}
+	if line.Received < 0 {
+		return app.NewError(nil, app.Econflict, "Purchase order line has less received than the reversal")
+	}
 
-		res = basepaper.DeliverBasePaperResponse(*req)
+	err = s.purchaseOrderRepo.UpdateLine(ctx, line)
+	if err != nil {
+		return err
+	}
 
+	if order.Status == "cancelled" || (order.Status == "closed" && !completed) {
 		return nil
-	})
+	}
 
-	return &res, err
+	order.Status = purchaseorder.OrderStatus(order.Lines)
+	order.UpdatedAt = now
+
+	return s.purchaseOrderRepo.Update(ctx, order)
 }
 
 func (s *service) Delete(ctx context.Context, basePaperID int64) error {
@@ -280,6 +1844,17 @@ func (s *service) Delete(ctx context.Context, basePaperID int64) error {
 		} else if err != nil {
 			return err
 		}
+		if err := s.mustNotTrackRolls(c, bp); err != nil {
+			return err
+		}
+
+		available, err := s.available(c, bp, nil)
+		if err != nil {
+			return err
+		}
+		if available < bp.Quantity {
+			return app.NewError(nil, app.EBadRequest, "Base paper has active reservations")
+		}
 
 		quantity := bp.Quantity
 
@@ -294,18 +1869,25 @@ func (s *service) Delete(ctx context.Context, basePaperID int64) error {
 		}
 
 		err = s.historyRepo.Create(c, &model.History{
-			BasePaper: model.BasePaper{ID: bp.ID},
-			Storage:   bp.Storage,
-			Member:    model.User{ID: memberID},
-			Status:    "deleted",
-			Affected:  quantity,
-			CreatedAt: time.Now().Unix(),
+			BasePaper:      model.BasePaper{ID: bp.ID},
+			Storage:        bp.Storage,
+			Member:         model.User{ID: memberID},
+			Status:         "deleted",
+			Affected:       quantity,
+			AffectedWeight: bp.Weight,
+			FromLocation:   db.NewNullString(bp.Location, true),
+			CreatedAt:      time.Now().Unix(),
 		})
 		if err != nil {
 			return err
 		}
 
-		return nil
+		_, err = s.valuationService.Issue(c, bp, quantity)
+		if err != nil {
+			return err
+		}
+
+		return s.thresholdService.Check(c, bp)
 	})
 
 	return err
@@ -0,0 +1,623 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/pkg/basepaper"
+	"github.com/bagus2x/tjiwi/pkg/history"
+	"github.com/bagus2x/tjiwi/pkg/location"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/purchaseorder"
+	"github.com/bagus2x/tjiwi/pkg/report"
+	"github.com/bagus2x/tjiwi/pkg/reservation"
+	"github.com/bagus2x/tjiwi/pkg/roll"
+	"github.com/bagus2x/tjiwi/pkg/storage"
+	stormemb "github.com/bagus2x/tjiwi/pkg/storagemember"
+	"github.com/bagus2x/tjiwi/pkg/threshold"
+	"github.com/bagus2x/tjiwi/pkg/valuation"
+	"github.com/bagus2x/tjiwi/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeBasePaperRepo struct {
+	basepaper.Repository
+	rows map[int64]*model.BasePaper
+}
+
+func sameSpec(a, b *model.BasePaper) bool {
+	return a.Storage.ID == b.Storage.ID && a.Gsm == b.Gsm && a.Width == b.Width && a.Io == b.Io &&
+		a.MaterialNumber == b.MaterialNumber && a.Location == b.Location
+}
+
+func (r *fakeBasePaperRepo) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func (r *fakeBasePaperRepo) FindByID(ctx context.Context, basePaperID int64) (*model.BasePaper, error) {
+	bp, ok := r.rows[basePaperID]
+	if !ok || bp.IsDeleted {
+		return nil, app.NewError(nil, app.ENotFound)
+	}
+	found := *bp
+
+	return &found, nil
+}
+
+func (r *fakeBasePaperRepo) FindBySpec(ctx context.Context, spec *model.BasePaper) (*model.BasePaper, error) {
+	for _, bp := range r.rows {
+		if !bp.IsDeleted && sameSpec(bp, spec) {
+			found := *bp
+			return &found, nil
+		}
+	}
+
+	return nil, app.NewError(nil, app.ENotFound)
+}
+
+func (r *fakeBasePaperRepo) Upsert(ctx context.Context, bp *model.BasePaper) error {
+	for _, row := range r.rows {
+		if sameSpec(row, bp) {
+			if row.Quantity == 0 || row.IsDeleted {
+				row.CreatedAt = bp.CreatedAt
+			}
+			row.Quantity += bp.Quantity
+			row.Weight += bp.Weight
+			row.UpdatedAt = bp.UpdatedAt
+			row.IsDeleted = false
+			bp.ID, bp.Quantity, bp.Weight, bp.CreatedAt = row.ID, row.Quantity, row.Weight, row.CreatedAt
+			return nil
+		}
+	}
+	bp.ID = int64(len(r.rows) + 1)
+	row := *bp
+	r.rows[bp.ID] = &row
+
+	return nil
+}
+
+func (r *fakeBasePaperRepo) Update(ctx context.Context, bp *model.BasePaper) error {
+	row := *bp
+	r.rows[bp.ID] = &row
+
+	return nil
+}
+
+func (r *fakeBasePaperRepo) FindInListBySpec(ctx context.Context, spec *model.BasePaper) ([]*model.BasePaper, error) {
+	basePapers := make([]*model.BasePaper, 0)
+	for _, bp := range r.rows {
+		if !bp.IsDeleted && bp.Location != "" && bp.Quantity > 0 && bp.Storage.ID == spec.Storage.ID && bp.Gsm == spec.Gsm &&
+			bp.Width == spec.Width && bp.Io == spec.Io && bp.MaterialNumber == spec.MaterialNumber {
+			found := *bp
+			basePapers = append(basePapers, &found)
+		}
+	}
+
+	return basePapers, nil
+}
+
+func (r *fakeBasePaperRepo) SumQuantityByLocation(ctx context.Context, storageID int64, location string) (int64, error) {
+	var sum int64
+	for _, bp := range r.rows {
+		if !bp.IsDeleted && bp.Storage.ID == storageID && bp.Location == location {
+			sum += bp.Quantity
+		}
+	}
+
+	return sum, nil
+}
+
+func (r *fakeBasePaperRepo) SumQuantityBySpec(ctx context.Context, spec *model.BasePaper) (int64, error) {
+	var sum int64
+	for _, bp := range r.rows {
+		if !bp.IsDeleted && bp.Storage.ID == spec.Storage.ID && bp.Gsm == spec.Gsm && bp.Width == spec.Width &&
+			bp.Io == spec.Io && bp.MaterialNumber == spec.MaterialNumber {
+			sum += bp.Quantity
+		}
+	}
+
+	return sum, nil
+}
+
+type fakeReservationRepo struct {
+	reservation.Repository
+	reservations []*model.Reservation
+}
+
+func (r *fakeReservationRepo) FindActiveBySpec(ctx context.Context, spec *model.BasePaper, now int64) ([]*model.Reservation, error) {
+	return r.reservations, nil
+}
+
+func (r *fakeReservationRepo) Update(ctx context.Context, res *model.Reservation) error {
+	return nil
+}
+
+type fakeHistoryRepo struct {
+	history.Repository
+	entries []*model.History
+}
+
+func (r *fakeHistoryRepo) Create(ctx context.Context, h *model.History) error {
+	h.ID = int64(len(r.entries) + 1)
+	entry := *h
+	r.entries = append(r.entries, &entry)
+
+	return nil
+}
+
+func (r *fakeHistoryRepo) FindByID(ctx context.Context, historyID int64) (*model.History, error) {
+	for _, h := range r.entries {
+		if h.ID == historyID {
+			found := *h
+			return &found, nil
+		}
+	}
+
+	return nil, app.NewError(nil, app.ENotFound)
+}
+
+func (r *fakeHistoryRepo) SumAffectedByReference(ctx context.Context, referenceID int64, status string) (int64, error) {
+	var sum int64
+	for _, h := range r.entries {
+		if h.Reference.Valid && h.Reference.Int64 == referenceID && h.Status == status {
+			sum += h.Affected
+		}
+	}
+
+	return sum, nil
+}
+
+type fakePurchaseOrderRepo struct {
+	purchaseorder.Repository
+	order *model.PurchaseOrder
+}
+
+func (r *fakePurchaseOrderRepo) FindByID(ctx context.Context, purchaseOrderID int64) (*model.PurchaseOrder, error) {
+	return r.order, nil
+}
+
+func (r *fakePurchaseOrderRepo) FindLineByID(ctx context.Context, lineID int64) (*model.PurchaseOrderLine, error) {
+	for _, line := range r.order.Lines {
+		if line.ID == lineID {
+			found := *line
+			return &found, nil
+		}
+	}
+
+	return nil, app.NewError(nil, app.ENotFound)
+}
+
+func (r *fakePurchaseOrderRepo) UpdateLine(ctx context.Context, line *model.PurchaseOrderLine) error {
+	return nil
+}
+
+func (r *fakePurchaseOrderRepo) Update(ctx context.Context, order *model.PurchaseOrder) error {
+	return nil
+}
+
+type fakeLocationRepo struct {
+	location.Repository
+	locations map[string]*model.Location
+}
+
+func (r *fakeLocationRepo) FindByCode(ctx context.Context, storageID int64, code string) (*model.Location, error) {
+	if loc, ok := r.locations[code]; ok {
+		return loc, nil
+	}
+
+	return &model.Location{Storage: model.Storage{ID: storageID}, Code: code, IsActive: true}, nil
+}
+
+type fakeStorMembRepo struct {
+	stormemb.Repository
+	outside map[int64]bool
+}
+
+func (r *fakeStorMembRepo) FindByStorageIDAndUserID(ctx context.Context, storageID, userID int64) (*model.StorageMember, error) {
+	if r.outside[storageID] {
+		return nil, app.NewError(nil, app.ENotFound)
+	}
+
+	return &model.StorageMember{IsAdmin: true, IsActive: true}, nil
+}
+
+type fakeRollRepo struct {
+	roll.Repository
+}
+
+func (r *fakeRollRepo) CountByBasePaperID(ctx context.Context, basePaperID int64) (int64, error) {
+	return 0, nil
+}
+
+func (r *fakeRollRepo) AttachToHistory(ctx context.Context, historyID int64, rolls []*model.Roll) error {
+	return nil
+}
+
+type fakeStorageRepo struct {
+	storage.Repository
+}
+
+func (r *fakeStorageRepo) FindByID(ctx context.Context, storageID int64) (*model.Storage, error) {
+	return &model.Storage{ID: storageID}, nil
+}
+
+type fakeValuationService struct {
+	valuation.Service
+}
+
+func (s *fakeValuationService) Restore(ctx context.Context, spec *model.BasePaper, quantity int64, historyID int64) error {
+	return nil
+}
+
+func (s *fakeValuationService) Issue(ctx context.Context, spec *model.BasePaper, quantity int64) ([]valuation.Issue, error) {
+	return nil, nil
+}
+
+func (s *fakeValuationService) IssueReceipt(ctx context.Context, spec *model.BasePaper, quantity int64, historyID int64) ([]valuation.Issue, error) {
+	return nil, nil
+}
+
+type fakeThresholdService struct {
+	threshold.Service
+	checked []int64
+}
+
+func (s *fakeThresholdService) Check(ctx context.Context, spec *model.BasePaper) error {
+	s.checked = append(s.checked, spec.Storage.ID)
+	return nil
+}
+
+func newTestService() (*service, *fakeBasePaperRepo, *fakeHistoryRepo) {
+	basePaperRepo := &fakeBasePaperRepo{rows: map[int64]*model.BasePaper{}}
+	historyRepo := &fakeHistoryRepo{}
+	s := &service{
+		basePaperRepo:    basePaperRepo,
+		historyRepo:      historyRepo,
+		storMembRepo:     &fakeStorMembRepo{outside: map[int64]bool{2: true}},
+		reservationRepo:  &fakeReservationRepo{},
+		rollRepo:         &fakeRollRepo{},
+		storageRepo:      &fakeStorageRepo{},
+		locationRepo:     &fakeLocationRepo{locations: map[string]*model.Location{}},
+		thresholdService: &fakeThresholdService{},
+		valuationService: &fakeValuationService{},
+	}
+
+	return s, basePaperRepo, historyRepo
+}
+
+func memberCtx(memberID int64) context.Context {
+	gc, _ := gin.CreateTestContext(httptest.NewRecorder())
+	gc.Set("userID", memberID)
+
+	return context.WithValue(context.Background(), utils.GinCtxKey{}, gc)
+}
+
+func seedDelivery(basePaperRepo *fakeBasePaperRepo, historyRepo *fakeHistoryRepo) *model.History {
+	bp := &model.BasePaper{Storage: model.Storage{ID: 1}, Gsm: 80, Width: 100, Io: 1, MaterialNumber: 7, Quantity: 5, Location: "A1"}
+	basePaperRepo.Upsert(context.Background(), bp)
+
+	h := &model.History{
+		BasePaper:    *bp,
+		Storage:      bp.Storage,
+		Status:       "delivered",
+		Affected:     5,
+		FromLocation: sql.NullString{String: "A1", Valid: true},
+	}
+	historyRepo.Create(context.Background(), h)
+
+	return h
+}
+
+func TestReturnAfterReverse(t *testing.T) {
+	s, basePaperRepo, historyRepo := newTestService()
+	delivered := seedDelivery(basePaperRepo, historyRepo)
+	ctx := memberCtx(1)
+
+	_, err := s.Reverse(ctx, &basepaper.ReverseHistoryRequest{HistoryID: delivered.ID})
+	assert.NoError(t, err)
+
+	_, err = s.Return(ctx, &basepaper.ReturnBasePaperRequest{HistoryID: delivered.ID, Quantity: 2})
+	assert.Equal(t, app.Econflict, app.ErrorCode(err))
+
+	bp, _ := basePaperRepo.FindBySpec(ctx, &model.BasePaper{Storage: model.Storage{ID: 1}, Gsm: 80, Width: 100, Io: 1, MaterialNumber: 7, Location: "A1"})
+	assert.Equal(t, int64(10), bp.Quantity)
+}
+
+func TestReverseAfterReturn(t *testing.T) {
+	s, basePaperRepo, historyRepo := newTestService()
+	delivered := seedDelivery(basePaperRepo, historyRepo)
+	ctx := memberCtx(1)
+
+	_, err := s.Return(ctx, &basepaper.ReturnBasePaperRequest{HistoryID: delivered.ID, Quantity: 2})
+	assert.NoError(t, err)
+
+	_, err = s.Reverse(ctx, &basepaper.ReverseHistoryRequest{HistoryID: delivered.ID})
+	assert.Equal(t, app.Econflict, app.ErrorCode(err))
+}
+
+func TestDeleteReservedBasePaper(t *testing.T) {
+	s, basePaperRepo, historyRepo := newTestService()
+	delivered := seedDelivery(basePaperRepo, historyRepo)
+	s.reservationRepo = &fakeReservationRepo{
+		reservations: []*model.Reservation{
+			{ID: 1, BasePaper: sql.NullInt64{Int64: delivered.BasePaper.ID, Valid: true}, Quantity: 2},
+		},
+	}
+
+	err := s.Delete(memberCtx(1), delivered.BasePaper.ID)
+	assert.Equal(t, app.EBadRequest, app.ErrorCode(err))
+}
+
+func TestReverseReceiptUpdatesPurchaseOrder(t *testing.T) {
+	s, basePaperRepo, historyRepo := newTestService()
+	order := &model.PurchaseOrder{
+		ID:     1,
+		Status: "closed",
+		Lines: []*model.PurchaseOrderLine{
+			{ID: 1, PurchaseOrder: model.PurchaseOrder{ID: 1}, Expected: 5, Received: 5},
+		},
+	}
+	s.purchaseOrderRepo = &fakePurchaseOrderRepo{order: order}
+
+	bp := &model.BasePaper{Storage: model.Storage{ID: 1}, Gsm: 80, Width: 100, Io: 1, MaterialNumber: 7, Quantity: 5}
+	basePaperRepo.Upsert(context.Background(), bp)
+
+	stored := &model.History{
+		BasePaper:         *bp,
+		Storage:           bp.Storage,
+		Status:            "stored",
+		Affected:          5,
+		ToLocation:        sql.NullString{String: "", Valid: true},
+		PurchaseOrderLine: sql.NullInt64{Int64: 1, Valid: true},
+	}
+	historyRepo.Create(context.Background(), stored)
+
+	_, err := s.Reverse(memberCtx(1), &basepaper.ReverseHistoryRequest{HistoryID: stored.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), order.Lines[0].Received)
+	assert.Equal(t, "open", order.Status)
+}
+
+func TestReturnChecksThreshold(t *testing.T) {
+	s, basePaperRepo, historyRepo := newTestService()
+	delivered := seedDelivery(basePaperRepo, historyRepo)
+	thresholdService := &fakeThresholdService{}
+	s.thresholdService = thresholdService
+
+	_, err := s.Return(memberCtx(1), &basepaper.ReturnBasePaperRequest{HistoryID: delivered.ID, Quantity: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, thresholdService.checked)
+}
+
+func TestDeliverRecordsDeliveryTime(t *testing.T) {
+	s, basePaperRepo, historyRepo := newTestService()
+	created := time.Now().Add(-72 * time.Hour).Unix()
+	bp := &model.BasePaper{Storage: model.Storage{ID: 1}, Gsm: 80, Width: 100, Io: 1, MaterialNumber: 7, Quantity: 5, Location: "A1", CreatedAt: created, UpdatedAt: created}
+	basePaperRepo.Upsert(context.Background(), bp)
+	historyRepo.Create(context.Background(), &model.History{
+		BasePaper:  *bp,
+		Storage:    bp.Storage,
+		Status:     "stored",
+		Affected:   5,
+		ToLocation: sql.NullString{String: "A1", Valid: true},
+		CreatedAt:  created,
+	})
+
+	before := time.Now().Unix()
+	_, err := s.Deliver(memberCtx(1), &basepaper.DeliverBasePaperRequest{ID: bp.ID, Quantity: 2})
+	assert.NoError(t, err)
+
+	delivered := historyRepo.entries[len(historyRepo.entries)-1]
+	assert.Equal(t, "delivered", delivered.Status)
+	assert.GreaterOrEqual(t, delivered.CreatedAt, before)
+	assert.Equal(t, delivered.CreatedAt, basePaperRepo.rows[bp.ID].UpdatedAt)
+
+	snapshotAt := func(at int64) int64 {
+		snapshot := report.NewSnapshot()
+		for _, h := range historyRepo.entries {
+			if h.CreatedAt > at {
+				continue
+			}
+			entry := *h
+			entry.BasePaper = *basePaperRepo.rows[h.BasePaper.ID]
+			snapshot.Apply(&entry)
+		}
+
+		quantity := int64(0)
+		for _, row := range snapshot.BasePapers(nil) {
+			quantity += row.Quantity
+		}
+
+		return quantity
+	}
+
+	assert.Equal(t, int64(5), snapshotAt(before-3600))
+	assert.Equal(t, int64(3), snapshotAt(delivered.CreatedAt))
+}
+
+func TestDeliverCountsOnDeliveryDay(t *testing.T) {
+	s, basePaperRepo, historyRepo := newTestService()
+	created := time.Now().Add(-48 * time.Hour).Unix()
+	bp := &model.BasePaper{Storage: model.Storage{ID: 1}, Gsm: 80, Width: 100, Io: 1, MaterialNumber: 7, Quantity: 5, Location: "A1", CreatedAt: created, UpdatedAt: created}
+	basePaperRepo.Upsert(context.Background(), bp)
+
+	_, err := s.Deliver(memberCtx(1), &basepaper.DeliverBasePaperRequest{ID: bp.ID, Quantity: 2})
+	assert.NoError(t, err)
+
+	now := time.Now()
+	today := report.StartOfDay(now, time.UTC)
+	creationDay := report.StartOfDay(time.Unix(created, 0), time.UTC)
+
+	delivered := historyRepo.entries[len(historyRepo.entries)-1]
+	assert.Equal(t, "delivered", delivered.Status)
+	assert.GreaterOrEqual(t, delivered.CreatedAt, today)
+	assert.GreaterOrEqual(t, delivered.CreatedAt, creationDay+24*3600)
+}
+
+func TestRefilledRowEntersAsNewStock(t *testing.T) {
+	s, basePaperRepo, _ := newTestService()
+	now := time.Now().Unix()
+	older := &model.BasePaper{Storage: model.Storage{ID: 1}, Gsm: 80, Width: 100, Io: 1, MaterialNumber: 7, Quantity: 2, Location: "A1", CreatedAt: now - 7200, UpdatedAt: now - 7200}
+	newer := &model.BasePaper{Storage: model.Storage{ID: 1}, Gsm: 80, Width: 100, Io: 1, MaterialNumber: 7, Quantity: 3, Location: "B1", CreatedAt: now - 3600, UpdatedAt: now - 3600}
+	buffer := &model.BasePaper{Storage: model.Storage{ID: 1}, Gsm: 80, Width: 100, Io: 1, MaterialNumber: 7, Quantity: 4, CreatedAt: now - 60, UpdatedAt: now - 60}
+	for _, bp := range []*model.BasePaper{older, newer, buffer} {
+		basePaperRepo.Upsert(context.Background(), bp)
+	}
+
+	_, err := s.Deliver(memberCtx(1), &basepaper.DeliverBasePaperRequest{ID: older.ID, Quantity: 2})
+	assert.NoError(t, err)
+
+	_, err = s.MoveToList(memberCtx(1), &basepaper.MoveToStorageRequest{ID: buffer.ID, Quantity: 4, Location: "A1"})
+	assert.NoError(t, err)
+
+	refilled := basePaperRepo.rows[older.ID]
+	assert.Equal(t, int64(4), refilled.Quantity)
+	assert.GreaterOrEqual(t, refilled.CreatedAt, now)
+
+	basePapers, err := basePaperRepo.FindInListBySpec(context.Background(), older)
+	assert.NoError(t, err)
+
+	available := func(bp *model.BasePaper) int64 {
+		return bp.Quantity
+	}
+
+	picks, short := basepaper.PickFIFO(basePapers, 5, available)
+	assert.Equal(t, int64(0), short)
+	assert.Equal(t, "B1", picks[0].Location)
+	assert.Equal(t, int64(3), picks[0].Quantity)
+	assert.Equal(t, "A1", picks[1].Location)
+	assert.Equal(t, int64(2), picks[1].Quantity)
+
+	oldest := basepaper.OlderStock(basePapers, refilled, available)
+	assert.NotNil(t, oldest)
+	assert.Equal(t, newer.ID, oldest.ID)
+}
+
+func TestExportRequiresMembership(t *testing.T) {
+	s, _, _ := newTestService()
+	storageID := int64(2)
+
+	var buf bytes.Buffer
+	err := s.Export(memberCtx(1), &basepaper.ExportBasePapersRequest{
+		Params: basepaper.Params{StorageID: &storageID},
+		Format: basepaper.ExportCSV,
+	}, &buf)
+	assert.Equal(t, app.EForbidden, app.ErrorCode(err))
+	assert.Equal(t, 0, buf.Len())
+}
+
+func TestImportRequiresMembership(t *testing.T) {
+	s, basePaperRepo, _ := newTestService()
+
+	_, err := s.Import(memberCtx(1), &basepaper.ImportBasePapersRequest{
+		StorageID: 2,
+		File:      strings.NewReader("gsm,width,io,material_number,quantity\n80,100,1,7,5\n"),
+	})
+	assert.Equal(t, app.EForbidden, app.ErrorCode(err))
+	assert.Len(t, basePaperRepo.rows, 0)
+}
+
+func TestRelocateRequiresMembership(t *testing.T) {
+	s, basePaperRepo, historyRepo := newTestService()
+	bp := &model.BasePaper{Storage: model.Storage{ID: 2}, Gsm: 80, Width: 100, Io: 1, MaterialNumber: 7, Quantity: 5, Location: "A1"}
+	basePaperRepo.Upsert(context.Background(), bp)
+
+	_, err := s.Relocate(memberCtx(1), &basepaper.RelocateBasePaperRequest{ID: bp.ID, Quantity: 2, Location: "B1"})
+	assert.Equal(t, app.EForbidden, app.ErrorCode(err))
+	assert.Equal(t, int64(5), basePaperRepo.rows[bp.ID].Quantity)
+	assert.Len(t, historyRepo.entries, 0)
+}
+
+func TestMoveToListCarriesReservation(t *testing.T) {
+	s, basePaperRepo, _ := newTestService()
+	bp := &model.BasePaper{Storage: model.Storage{ID: 1}, Gsm: 80, Width: 100, Io: 1, MaterialNumber: 7, Quantity: 4}
+	basePaperRepo.Upsert(context.Background(), bp)
+
+	reserved := &model.Reservation{ID: 1, BasePaper: sql.NullInt64{Int64: bp.ID, Valid: true}, Quantity: 3, Status: "active"}
+	s.reservationRepo = &fakeReservationRepo{reservations: []*model.Reservation{reserved}}
+
+	_, err := s.MoveToList(memberCtx(1), &basepaper.MoveToStorageRequest{ID: bp.ID, Quantity: 2, Location: "A1"})
+	assert.Equal(t, app.EBadRequest, app.ErrorCode(err))
+
+	res, err := s.MoveToList(memberCtx(1), &basepaper.MoveToStorageRequest{ID: bp.ID, Quantity: 3, Location: "A1"})
+	assert.NoError(t, err)
+	assert.Equal(t, res.ID, reserved.BasePaper.Int64)
+	assert.Equal(t, int64(1), basePaperRepo.rows[bp.ID].Quantity)
+}
+
+func TestReverseStoreKeepsReservedStock(t *testing.T) {
+	s, basePaperRepo, historyRepo := newTestService()
+	bp := &model.BasePaper{Storage: model.Storage{ID: 1}, Gsm: 80, Width: 100, Io: 1, MaterialNumber: 7, Quantity: 5, Location: "A1"}
+	basePaperRepo.Upsert(context.Background(), bp)
+	stored := &model.History{BasePaper: *bp, Storage: bp.Storage, Status: "stored", Affected: 3, ToLocation: sql.NullString{String: "A1", Valid: true}}
+	historyRepo.Create(context.Background(), stored)
+
+	s.reservationRepo = &fakeReservationRepo{reservations: []*model.Reservation{
+		{ID: 1, BasePaper: sql.NullInt64{Int64: bp.ID, Valid: true}, Quantity: 4, Status: "active"},
+	}}
+
+	_, err := s.Reverse(memberCtx(1), &basepaper.ReverseHistoryRequest{HistoryID: stored.ID})
+	assert.Equal(t, app.EBadRequest, app.ErrorCode(err))
+	assert.Equal(t, int64(5), basePaperRepo.rows[bp.ID].Quantity)
+}
+
+func TestReverseRelocateChecksCapacity(t *testing.T) {
+	s, basePaperRepo, historyRepo := newTestService()
+	full := &model.BasePaper{Storage: model.Storage{ID: 1}, Gsm: 80, Width: 100, Io: 1, MaterialNumber: 7, Quantity: 5, Location: "A1"}
+	moved := &model.BasePaper{Storage: model.Storage{ID: 1}, Gsm: 80, Width: 100, Io: 1, MaterialNumber: 7, Quantity: 2, Location: "B1"}
+	basePaperRepo.Upsert(context.Background(), full)
+	basePaperRepo.Upsert(context.Background(), moved)
+	relocated := &model.History{
+		BasePaper:    *moved,
+		Storage:      moved.Storage,
+		Status:       "relocated",
+		Affected:     2,
+		FromLocation: sql.NullString{String: "A1", Valid: true},
+		ToLocation:   sql.NullString{String: "B1", Valid: true},
+	}
+	historyRepo.Create(context.Background(), relocated)
+
+	s.locationRepo = &fakeLocationRepo{locations: map[string]*model.Location{
+		"A1": {Code: "A1", Capacity: sql.NullInt64{Int64: 5, Valid: true}, IsActive: true},
+	}}
+
+	_, err := s.Reverse(memberCtx(1), &basepaper.ReverseHistoryRequest{HistoryID: relocated.ID})
+	assert.Equal(t, app.EBadRequest, app.ErrorCode(err))
+	assert.Len(t, historyRepo.entries, 1)
+}
+
+func TestReverseDeliveryOrderDelivery(t *testing.T) {
+	s, basePaperRepo, historyRepo := newTestService()
+	delivered := seedDelivery(basePaperRepo, historyRepo)
+	historyRepo.entries[0].DeliveryOrder = sql.NullInt64{Int64: 3, Valid: true}
+
+	_, err := s.Reverse(memberCtx(1), &basepaper.ReverseHistoryRequest{HistoryID: delivered.ID})
+	assert.Equal(t, app.Econflict, app.ErrorCode(err))
+	assert.Len(t, historyRepo.entries, 1)
+}
+
+func TestAdjustChecksCapacity(t *testing.T) {
+	s, basePaperRepo, historyRepo := newTestService()
+	bp := &model.BasePaper{Storage: model.Storage{ID: 1}, Gsm: 80, Width: 100, Io: 1, MaterialNumber: 7, Quantity: 4, Location: "A1"}
+	basePaperRepo.Upsert(context.Background(), bp)
+
+	s.locationRepo = &fakeLocationRepo{locations: map[string]*model.Location{
+		"A1": {Code: "A1", Capacity: sql.NullInt64{Int64: 5, Valid: true}, IsActive: true},
+	}}
+
+	_, err := s.Adjust(memberCtx(1), &basepaper.AdjustBasePaperRequest{ID: bp.ID, Delta: 2, Reason: "found"})
+	assert.Equal(t, app.EBadRequest, app.ErrorCode(err))
+	assert.Equal(t, int64(4), basePaperRepo.rows[bp.ID].Quantity)
+	assert.Len(t, historyRepo.entries, 0)
+
+	_, err = s.Adjust(memberCtx(1), &basepaper.AdjustBasePaperRequest{ID: bp.ID, Delta: 1, Reason: "found"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), basePaperRepo.rows[bp.ID].Quantity)
+}
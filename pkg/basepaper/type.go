@@ -1,7 +1,11 @@
 package basepaper
 
 import (
+	"fmt"
+	"io"
+
 	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/pkg/roll"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -23,44 +27,75 @@ type Cursor struct {
 }
 
 type AddBasePaperRequest struct {
-	StorageID      int64 `json:"storageID" validate:"required"`
-	Gsm            int64 `json:"gsm" validate:"required"`
-	Width          int64 `json:"width" validate:"required"`
-	Io             int64 `json:"io" validate:"required"`
-	MaterialNumber int64 `json:"materialNumber" validate:"required"`
-	Quantity       int64 `json:"quantity" validate:"required"`
+	StorageID           int64    `json:"storageID" validate:"required"`
+	Gsm                 int64    `json:"gsm" validate:"required"`
+	Width               int64    `json:"width" validate:"required"`
+	Io                  int64    `json:"io" validate:"required"`
+	MaterialNumber      int64    `json:"materialNumber" validate:"required"`
+	Quantity            int64    `json:"quantity" validate:"required,gt=0"`
+	RollWeight          float64  `json:"rollWeight" validate:"omitempty,gt=0"`
+	Length              int64    `json:"length" validate:"omitempty,gt=0"`
+	UnitCost            float64  `json:"unitCost" validate:"omitempty,gt=0"`
+	Serials             []string `json:"serials" validate:"omitempty,dive,required,lte=64"`
+	PurchaseOrderLineID int64    `json:"-"`
 }
 
 func (r *AddBasePaperRequest) Validate() error {
 	validate := validator.New()
 	err := validate.Struct(r)
+	if err != nil {
+		return app.ValidateAndTranslate(validate, err)
+	}
 
-	return app.ValidateAndTranslate(validate, err)
+	if len(r.Serials) != 0 && int64(len(r.Serials)) != r.Quantity {
+		return app.NewError(nil, app.EBadRequest, "Number of serials must match the quantity")
+	}
+	if duplicates := roll.Duplicates(r.Serials); len(duplicates) != 0 {
+		return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Roll %s is listed more than once", duplicates[0]))
+	}
+
+	return nil
+}
+
+func (r *AddBasePaperRequest) TotalWeight() float64 {
+	if r.RollWeight > 0 {
+		return RoundWeight(r.RollWeight * float64(r.Quantity))
+	}
+	if r.Length > 0 {
+		return RoundWeight(RollWeight(r.Gsm, r.Width, r.Length) * float64(r.Quantity))
+	}
+
+	return 0
 }
 
 type AddBasePaperResponse struct {
-	ID             int64 `json:"id"`
-	StorageID      int64 `json:"storageID"`
-	Gsm            int64 `json:"gsm"`
-	Width          int64 `json:"width"`
-	Io             int64 `json:"io"`
-	MaterialNumber int64 `json:"materialNumber"`
-	Quantity       int64 `json:"quantity"`
-	CreatedAt      int64 `json:"createdAt"`
-	UpdatedAt      int64 `json:"updatedAt"`
+	ID             int64   `json:"id"`
+	StorageID      int64   `json:"storageID"`
+	Gsm            int64   `json:"gsm"`
+	Width          int64   `json:"width"`
+	Io             int64   `json:"io"`
+	MaterialNumber int64   `json:"materialNumber"`
+	Quantity       int64   `json:"quantity"`
+	Weight         float64 `json:"weight"`
+	CreatedAt      int64   `json:"createdAt"`
+	UpdatedAt      int64   `json:"updatedAt"`
 }
 
 type GetBasePaperResponse struct {
-	ID             int64  `json:"id"`
-	StorageID      int64  `json:"storageID"`
-	Gsm            int64  `json:"gsm"`
-	Width          int64  `json:"width"`
-	Io             int64  `json:"io"`
-	MaterialNumber int64  `json:"materialNumber"`
-	Location       string `json:"location,omitempty"`
-	Quantity       int64  `json:"quantity"`
-	CreatedAt      int64  `json:"createdAt"`
-	UpdatedAt      int64  `json:"updatedAt"`
+	ID                  int64   `json:"id"`
+	StorageID           int64   `json:"storageID"`
+	Gsm                 int64   `json:"gsm"`
+	Width               int64   `json:"width"`
+	Io                  int64   `json:"io"`
+	MaterialNumber      int64   `json:"materialNumber"`
+	MaterialDescription string  `json:"materialDescription"`
+	Location            string  `json:"location,omitempty"`
+	Quantity            int64   `json:"quantity"`
+	Weight              float64 `json:"weight"`
+	Available           int64   `json:"available"`
+	Reserved            int64   `json:"reserved"`
+	CreatedAt           int64   `json:"createdAt"`
+	UpdatedAt           int64   `json:"updatedAt"`
 }
 
 type GetBasePapersResponse struct {
@@ -69,32 +104,247 @@ type GetBasePapersResponse struct {
 }
 
 type MoveToStorageRequest struct {
-	ID       int64  `json:"id"`
-	Location string `json:"location"`
-	Quantity int64  `json:"quantity"`
+	ID       int64    `json:"id"`
+	Location string   `json:"location"`
+	Quantity int64    `json:"quantity"`
+	Serials  []string `json:"serials"`
 }
 
 type MoveToStorageResponse struct {
-	ID             int64  `json:"id"`
-	StorageID      int64  `json:"storageID"`
-	Gsm            int64  `json:"gsm"`
-	Width          int64  `json:"width"`
-	Io             int64  `json:"io"`
-	MaterialNumber int64  `json:"materialNumber"`
-	Quantity       int64  `json:"quantity"`
-	Location       string `json:"location"`
-	CreatedAt      int64  `json:"createdAt"`
-	UpdatedAt      int64  `json:"updatedAt"`
+	ID             int64    `json:"id"`
+	StorageID      int64    `json:"storageID"`
+	Gsm            int64    `json:"gsm"`
+	Width          int64    `json:"width"`
+	Io             int64    `json:"io"`
+	MaterialNumber int64    `json:"materialNumber"`
+	Quantity       int64    `json:"quantity"`
+	Weight         float64  `json:"weight"`
+	Location       string   `json:"location"`
+	Serials        []string `json:"serials,omitempty"`
+	CreatedAt      int64    `json:"createdAt"`
+	UpdatedAt      int64    `json:"updatedAt"`
 }
 
 type DeliverBasePaperRequest struct {
-	ID       int64 `json:"id"`
-	Quantity int64 `json:"quantity"`
-	MemberID int64 `json:"memberID"`
+	ID              int64    `json:"id"`
+	Quantity        int64    `json:"quantity"`
+	MemberID        int64    `json:"memberID"`
+	ReservationID   int64    `json:"reservationID,omitempty"`
+	Serials         []string `json:"serials"`
+	OverrideFifo    bool     `json:"overrideFifo"`
+	DeliveryOrderID int64    `json:"-"`
 }
 
 type DeliverBasePaperResponse struct {
-	ID       int64 `json:"id"`
-	Quantity int64 `json:"quantity"`
-	MemberID int64 `json:"memberID"`
+	ID              int64    `json:"id"`
+	Quantity        int64    `json:"quantity"`
+	Weight          float64  `json:"weight"`
+	MemberID        int64    `json:"memberID"`
+	ReservationID   int64    `json:"reservationID,omitempty"`
+	Serials         []string `json:"serials,omitempty"`
+	DeliveryOrderID int64    `json:"deliveryOrderID,omitempty"`
+}
+
+type PickPlanRequest struct {
+	StorageID      int64 `form:"-" validate:"required"`
+	Gsm            int64 `form:"gsm" validate:"required"`
+	Width          int64 `form:"width" validate:"required"`
+	Io             int64 `form:"io" validate:"required"`
+	MaterialNumber int64 `form:"material" validate:"required"`
+	Quantity       int64 `form:"quantity" validate:"required,gt=0"`
+}
+
+func (r *PickPlanRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type PickResponse struct {
+	BasePaperID int64  `json:"basePaperID"`
+	Location    string `json:"location"`
+	Quantity    int64  `json:"quantity"`
+	CreatedAt   int64  `json:"createdAt"`
+}
+
+type PickPlanResponse struct {
+	StorageID      int64           `json:"storageID"`
+	Gsm            int64           `json:"gsm"`
+	Width          int64           `json:"width"`
+	Io             int64           `json:"io"`
+	MaterialNumber int64           `json:"materialNumber"`
+	Quantity       int64           `json:"quantity"`
+	Picked         int64           `json:"picked"`
+	Short          int64           `json:"short"`
+	EnforceFifo    bool            `json:"enforceFifo"`
+	Picks          []*PickResponse `json:"picks"`
+}
+
+type ImportBasePapersRequest struct {
+	StorageID int64
+	DryRun    bool
+	File      io.Reader
+}
+
+type ImportRowError struct {
+	Row      int      `json:"row"`
+	Messages []string `json:"messages"`
+}
+
+type ImportBasePapersResponse struct {
+	DryRun     bool                    `json:"dryRun"`
+	Total      int                     `json:"total"`
+	Valid      int                     `json:"valid"`
+	Errors     []ImportRowError        `json:"errors"`
+	BasePapers []*AddBasePaperResponse `json:"basePapers"`
+}
+
+type ExportBasePapersRequest struct {
+	Params   Params
+	Format   string
+	Timezone string
+}
+
+type TransferBasePaperRequest struct {
+	ID              int64 `json:"id" validate:"required"`
+	TargetStorageID int64 `json:"targetStorageID" validate:"required"`
+	Quantity        int64 `json:"quantity" validate:"required,gt=0"`
+}
+
+func (r *TransferBasePaperRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type TransferBasePaperResponse struct {
+	SourceID           int64   `json:"sourceID"`
+	TargetID           int64   `json:"targetID"`
+	SourceStorageID    int64   `json:"sourceStorageID"`
+	TargetStorageID    int64   `json:"targetStorageID"`
+	Quantity           int64   `json:"quantity"`
+	Weight             float64 `json:"weight"`
+	TransferOutHistory int64   `json:"transferOutHistory"`
+	TransferInHistory  int64   `json:"transferInHistory"`
+	CreatedAt          int64   `json:"createdAt"`
+}
+
+type RelocateBasePaperRequest struct {
+	ID       int64    `json:"id" validate:"required"`
+	Location string   `json:"location" validate:"required,lte=10"`
+	Quantity int64    `json:"quantity" validate:"required,gt=0"`
+	Serials  []string `json:"serials"`
+}
+
+func (r *RelocateBasePaperRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type RelocateBasePaperResponse struct {
+	ID             int64    `json:"id"`
+	SourceID       int64    `json:"sourceID"`
+	StorageID      int64    `json:"storageID"`
+	Gsm            int64    `json:"gsm"`
+	Width          int64    `json:"width"`
+	Io             int64    `json:"io"`
+	MaterialNumber int64    `json:"materialNumber"`
+	Quantity       int64    `json:"quantity"`
+	Weight         float64  `json:"weight"`
+	FromLocation   string   `json:"fromLocation"`
+	ToLocation     string   `json:"toLocation"`
+	Serials        []string `json:"serials,omitempty"`
+	CreatedAt      int64    `json:"createdAt"`
+	UpdatedAt      int64    `json:"updatedAt"`
+}
+
+type ReturnBasePaperRequest struct {
+	HistoryID int64  `json:"historyID" validate:"required"`
+	Quantity  int64  `json:"quantity" validate:"required,gt=0"`
+	Location  string `json:"location" validate:"lte=10"`
+}
+
+func (r *ReturnBasePaperRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type ReturnBasePaperResponse struct {
+	ID             int64   `json:"id"`
+	HistoryID      int64   `json:"historyID"`
+	ReferenceID    int64   `json:"referenceID"`
+	StorageID      int64   `json:"storageID"`
+	Gsm            int64   `json:"gsm"`
+	Width          int64   `json:"width"`
+	Io             int64   `json:"io"`
+	MaterialNumber int64   `json:"materialNumber"`
+	Quantity       int64   `json:"quantity"`
+	Weight         float64 `json:"weight"`
+	Location       string  `json:"location"`
+	CreatedAt      int64   `json:"createdAt"`
+}
+
+type ReverseHistoryRequest struct {
+	HistoryID int64 `json:"historyID" validate:"required"`
+}
+
+func (r *ReverseHistoryRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type ReverseHistoryResponse struct {
+	ID          int64   `json:"id"`
+	ReferenceID int64   `json:"referenceID"`
+	BasePaperID int64   `json:"basePaperID"`
+	StorageID   int64   `json:"storageID"`
+	Status      string  `json:"status"`
+	Affected    int64   `json:"affected"`
+	Weight      float64 `json:"weight"`
+	CreatedAt   int64   `json:"createdAt"`
+}
+
+type AdjustBasePaperRequest struct {
+	ID     int64  `json:"id" validate:"required"`
+	Delta  int64  `json:"delta" validate:"required"`
+	Reason string `json:"reason" validate:"required,oneof=damaged miscount lost found"`
+	Note   string `json:"note" validate:"lte=512"`
+}
+
+func (r *AdjustBasePaperRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return app.ValidateAndTranslate(validate, err)
+	}
+
+	if (r.Reason == "damaged" || r.Reason == "lost") && r.Delta > 0 {
+		return app.NewError(nil, app.EBadRequest, "Delta must be negative for damaged or lost base papers")
+	}
+	if r.Reason == "found" && r.Delta < 0 {
+		return app.NewError(nil, app.EBadRequest, "Delta must be positive for found base papers")
+	}
+
+	return nil
+}
+
+type AdjustBasePaperResponse struct {
+	ID        int64   `json:"id"`
+	HistoryID int64   `json:"historyID"`
+	StorageID int64   `json:"storageID"`
+	Location  string  `json:"location"`
+	Delta     int64   `json:"delta"`
+	Quantity  int64   `json:"quantity"`
+	Weight    float64 `json:"weight"`
+	Reason    string  `json:"reason"`
+	Note      string  `json:"note"`
+	UpdatedAt int64   `json:"updatedAt"`
 }
@@ -3,8 +3,24 @@ package basepaper
 import (
 	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestToUpper(t *testing.T) {
 	t.Log(strings.ToUpper("a6"))
 }
+
+func TestAdjustBasePaperRequestValidate(t *testing.T) {
+	req := AdjustBasePaperRequest{ID: 1, Delta: -2, Reason: "damaged"}
+	assert.NoError(t, req.Validate())
+
+	req = AdjustBasePaperRequest{ID: 1, Delta: 2, Reason: "lost"}
+	assert.Error(t, req.Validate())
+
+	req = AdjustBasePaperRequest{ID: 1, Delta: -1, Reason: "found"}
+	assert.Error(t, req.Validate())
+
+	req = AdjustBasePaperRequest{ID: 1, Delta: 3, Reason: "stolen"}
+	assert.Error(t, req.Validate())
+}
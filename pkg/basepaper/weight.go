@@ -0,0 +1,30 @@
+package basepaper
+
+import (
+	"math"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+func RollWeight(gsm, width, length int64) float64 {
+	return RoundWeight(float64(gsm) * float64(width) * float64(length) / 1e6)
+}
+
+func RoundWeight(weight float64) float64 {
+	return math.Round(weight*1000) / 1000
+}
+
+func Share(weight float64, part, whole int64) float64 {
+	if part == whole {
+		return weight
+	}
+	if whole == 0 {
+		return 0
+	}
+
+	return RoundWeight(weight * float64(part) / float64(whole))
+}
+
+func WeightOf(bp *model.BasePaper, quantity int64) float64 {
+	return Share(bp.Weight, quantity, bp.Quantity)
+}
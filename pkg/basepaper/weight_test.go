@@ -0,0 +1,37 @@
+package basepaper
+
+import (
+	"testing"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollWeight(t *testing.T) {
+	assert.Equal(t, 960.0, RollWeight(80, 1200, 10000))
+	assert.Equal(t, 0.0, RollWeight(80, 1200, 0))
+}
+
+func TestShare(t *testing.T) {
+	assert.Equal(t, 100.5, Share(100.5, 3, 3))
+	assert.Equal(t, 33.5, Share(100.5, 1, 3))
+	assert.Equal(t, 0.0, Share(100.5, 1, 0))
+	assert.Equal(t, 201.0, Share(100.5, 6, 3))
+}
+
+func TestWeightOf(t *testing.T) {
+	bp := model.BasePaper{Quantity: 4, Weight: 3200}
+	assert.Equal(t, 1600.0, WeightOf(&bp, 2))
+	assert.Equal(t, 3200.0, WeightOf(&bp, 4))
+}
+
+func TestAddBasePaperRequestTotalWeight(t *testing.T) {
+	req := AddBasePaperRequest{Gsm: 80, Width: 1200, Quantity: 2, Length: 10000}
+	assert.Equal(t, 1920.0, req.TotalWeight())
+
+	req.RollWeight = 950.25
+	assert.Equal(t, 1900.5, req.TotalWeight())
+
+	req = AddBasePaperRequest{Gsm: 80, Width: 1200, Quantity: 2}
+	assert.Equal(t, 0.0, req.TotalWeight())
+}
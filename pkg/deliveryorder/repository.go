@@ -0,0 +1,17 @@
+package deliveryorder
+
+import (
+	"context"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, order *model.DeliveryOrder) error
+	FindByID(ctx context.Context, deliveryOrderID int64) (*model.DeliveryOrder, error)
+	FindByNumber(ctx context.Context, storageID int64, number string) (*model.DeliveryOrder, error)
+	FindByStorageID(ctx context.Context, storageID int64) ([]*model.DeliveryOrder, error)
+	Update(ctx context.Context, order *model.DeliveryOrder) error
+	ReplaceLines(ctx context.Context, order *model.DeliveryOrder) error
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+}
@@ -0,0 +1,329 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/deliveryorder"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/sirupsen/logrus"
+)
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) deliveryorder.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Create(ctx context.Context, order *model.DeliveryOrder) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			INSERT INTO
+				Delivery_Order
+				(storage_id, number, customer, status, member_id, shipped_at, created_at, updated_at)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING
+				id
+	`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		order.Storage.ID,
+		order.Number,
+		order.Customer,
+		order.Status,
+		order.Member.ID,
+		order.ShippedAt,
+		order.CreatedAt,
+		order.UpdatedAt,
+	).Scan(&order.ID)
+	if err != nil {
+		return err
+	}
+
+	return r.createLines(ctx, order)
+}
+
+func (r *repository) FindByID(ctx context.Context, deliveryOrderID int64) (*model.DeliveryOrder, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, storage_id, number, customer, status, member_id, shipped_at, created_at, updated_at
+			FROM
+				Delivery_Order
+			WHERE
+				id = $1
+			FOR UPDATE
+	`
+
+	order, err := scanDeliveryOrder(tx.QueryRowContext(ctx, query, deliveryOrderID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, app.NewError(err, app.ENotFound)
+		}
+		return nil, err
+	}
+
+	order.Lines, err = r.findLines(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (r *repository) FindByNumber(ctx context.Context, storageID int64, number string) (*model.DeliveryOrder, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, storage_id, number, customer, status, member_id, shipped_at, created_at, updated_at
+			FROM
+				Delivery_Order
+			WHERE
+				storage_id = $1 AND number = $2
+	`
+
+	order, err := scanDeliveryOrder(tx.QueryRowContext(ctx, query, storageID, number))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, app.NewError(err, app.ENotFound)
+		}
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (r *repository) FindByStorageID(ctx context.Context, storageID int64) ([]*model.DeliveryOrder, error) {
+	query := `
+			SELECT
+				id, storage_id, number, customer, status, member_id, shipped_at, created_at, updated_at
+			FROM
+				Delivery_Order
+			WHERE
+				storage_id = $1
+			ORDER BY
+				id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orders := make([]*model.DeliveryOrder, 0)
+
+	for rows.Next() {
+		order, err := scanDeliveryOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, order)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, order := range orders {
+		order.Lines, err = r.findLines(ctx, order.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return orders, nil
+}
+
+func (r *repository) Update(ctx context.Context, order *model.DeliveryOrder) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			UPDATE
+				Delivery_Order
+			SET
+				customer = $1,
+				status = $2,
+				shipped_at = $3,
+				updated_at = $4
+			WHERE
+				id = $5
+	`
+
+	res, err := tx.ExecContext(ctx, query, order.Customer, order.Status, order.ShippedAt, order.UpdatedAt, order.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return app.NewError(nil, app.ENotFound)
+	}
+
+	return nil
+}
+
+func (r *repository) ReplaceLines(ctx context.Context, order *model.DeliveryOrder) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			DELETE FROM
+				Delivery_Order_Line
+			WHERE
+				delivery_order_id = $1
+	`
+
+	_, err := tx.ExecContext(ctx, query, order.ID)
+	if err != nil {
+		return err
+	}
+
+	return r.createLines(ctx, order)
+}
+
+func (r *repository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	c := context.WithValue(ctx, db.TransactionKey{}, tx)
+	err = fn(c)
+	if err != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			logrus.Error("Failed to rollback transaction", errTx)
+		}
+		return err
+	}
+
+	if errTX := tx.Commit(); errTX != nil {
+		logrus.Error("Failed to commmit transaction", errTX)
+	}
+
+	return nil
+}
+
+func (r *repository) createLines(ctx context.Context, order *model.DeliveryOrder) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			INSERT INTO
+				Delivery_Order_Line
+				(delivery_order_id, gsm, width, io, material_number, quantity)
+			VALUES
+				($1, $2, $3, $4, $5, $6)
+			RETURNING
+				id
+	`
+
+	for _, line := range order.Lines {
+		line.DeliveryOrder.ID = order.ID
+
+		err := tx.QueryRowContext(
+			ctx,
+			query,
+			line.DeliveryOrder.ID,
+			line.Gsm,
+			line.Width,
+			line.Io,
+			line.MaterialNumber,
+			line.Quantity,
+		).Scan(&line.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *repository) findLines(ctx context.Context, deliveryOrderID int64) ([]*model.DeliveryOrderLine, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, delivery_order_id, gsm, width, io, material_number, quantity
+			FROM
+				Delivery_Order_Line
+			WHERE
+				delivery_order_id = $1
+			ORDER BY
+				id ASC
+	`
+
+	rows, err := tx.QueryContext(ctx, query, deliveryOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	lines := make([]*model.DeliveryOrderLine, 0)
+
+	for rows.Next() {
+		var line model.DeliveryOrderLine
+
+		err := rows.Scan(
+			&line.ID,
+			&line.DeliveryOrder.ID,
+			&line.Gsm,
+			&line.Width,
+			&line.Io,
+			&line.MaterialNumber,
+			&line.Quantity,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		lines = append(lines, &line)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanDeliveryOrder(row scanner) (*model.DeliveryOrder, error) {
+	var order model.DeliveryOrder
+
+	err := row.Scan(
+		&order.ID,
+		&order.Storage.ID,
+		&order.Number,
+		&order.Customer,
+		&order.Status,
+		&order.Member.ID,
+		&order.ShippedAt,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
@@ -0,0 +1,13 @@
+package deliveryorder
+
+import "context"
+
+type Service interface {
+	Create(ctx context.Context, req *CreateDeliveryOrderRequest) (*DeliveryOrderResponse, error)
+	GetByID(ctx context.Context, deliveryOrderID int64) (*DeliveryOrderResponse, error)
+	GetByStorageID(ctx context.Context, storageID int64) ([]*DeliveryOrderResponse, error)
+	Update(ctx context.Context, req *UpdateDeliveryOrderRequest) (*DeliveryOrderResponse, error)
+	Pick(ctx context.Context, deliveryOrderID int64) (*DeliveryOrderResponse, error)
+	Ship(ctx context.Context, deliveryOrderID int64) (*DeliveryOrderResponse, error)
+	Cancel(ctx context.Context, deliveryOrderID int64) (*DeliveryOrderResponse, error)
+}
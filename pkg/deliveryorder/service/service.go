@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/basepaper"
+	"github.com/bagus2x/tjiwi/pkg/deliveryorder"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/reservation"
+	stormemb "github.com/bagus2x/tjiwi/pkg/storagemember"
+	"github.com/bagus2x/tjiwi/utils"
+)
+
+type service struct {
+	deliveryOrderRepo deliveryorder.Repository
+	basePaperRepo     basepaper.Repository
+	storMembRepo      stormemb.Repository
+	reservationRepo   reservation.Repository
+	basePaperService  basepaper.Service
+}
+
+func New(deliveryOrderRepo deliveryorder.Repository, basePaperRepo basepaper.Repository, storMembRepo stormemb.Repository, reservationRepo reservation.Repository, basePaperService basepaper.Service) deliveryorder.Service {
+	return &service{
+		deliveryOrderRepo: deliveryOrderRepo,
+		basePaperRepo:     basePaperRepo,
+		storMembRepo:      storMembRepo,
+		reservationRepo:   reservationRepo,
+		basePaperService:  basePaperService,
+	}
+}
+
+func (s *service) Create(ctx context.Context, req *deliveryorder.CreateDeliveryOrderRequest) (*deliveryorder.DeliveryOrderResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, req.StorageID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	order := model.DeliveryOrder{
+		Storage:   model.Storage{ID: req.StorageID},
+		Number:    req.Number,
+		Customer:  req.Customer,
+		Status:    "draft",
+		Member:    model.User{ID: memberID},
+		Lines:     toLines(req.Lines),
+		CreatedAt: time.Now().Unix(),
+		UpdatedAt: time.Now().Unix(),
+	}
+
+	err = s.deliveryOrderRepo.WithTransaction(ctx, func(c context.Context) error {
+		_, err := s.deliveryOrderRepo.FindByNumber(c, req.StorageID, req.Number)
+		if err == nil {
+			return app.NewError(nil, app.Econflict, "Delivery order number already exist")
+		} else if app.ErrorCode(err) != app.ENotFound {
+			return err
+		}
+
+		return s.deliveryOrderRepo.Create(c, &order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDeliveryOrderResponse(&order), nil
+}
+
+func (s *service) GetByID(ctx context.Context, deliveryOrderID int64) (*deliveryorder.DeliveryOrderResponse, error) {
+	order, err := s.findDeliveryOrder(ctx, deliveryOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDeliveryOrderResponse(order), nil
+}
+
+func (s *service) GetByStorageID(ctx context.Context, storageID int64) ([]*deliveryorder.DeliveryOrderResponse, error) {
+	orders, err := s.deliveryOrderRepo.FindByStorageID(ctx, storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*deliveryorder.DeliveryOrderResponse, 0)
+	for _, order := range orders {
+		res = append(res, toDeliveryOrderResponse(order))
+	}
+
+	return res, nil
+}
+
+func (s *service) Update(ctx context.Context, req *deliveryorder.UpdateDeliveryOrderRequest) (*deliveryorder.DeliveryOrderResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var order *model.DeliveryOrder
+
+	err := s.deliveryOrderRepo.WithTransaction(ctx, func(c context.Context) error {
+		var err error
+		order, err = s.findDeliveryOrder(c, req.ID)
+		if err != nil {
+			return err
+		}
+		if order.Status != "draft" {
+			return app.NewError(nil, app.EBadRequest, "Only draft delivery orders can be changed")
+		}
+
+		order.Customer = req.Customer
+		order.Lines = toLines(req.Lines)
+		order.UpdatedAt = time.Now().Unix()
+
+		err = s.deliveryOrderRepo.Update(c, order)
+		if err != nil {
+			return err
+		}
+
+		return s.deliveryOrderRepo.ReplaceLines(c, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDeliveryOrderResponse(order), nil
+}
+
+func (s *service) Pick(ctx context.Context, deliveryOrderID int64) (*deliveryorder.DeliveryOrderResponse, error) {
+	return s.transition(ctx, deliveryOrderID, "picking")
+}
+
+func (s *service) Cancel(ctx context.Context, deliveryOrderID int64) (*deliveryorder.DeliveryOrderResponse, error) {
+	return s.transition(ctx, deliveryOrderID, "cancelled")
+}
+
+func (s *service) Ship(ctx context.Context, deliveryOrderID int64) (*deliveryorder.DeliveryOrderResponse, error) {
+	var res *deliveryorder.DeliveryOrderResponse
+
+	err := s.deliveryOrderRepo.WithTransaction(ctx, func(c context.Context) error {
+		order, err := s.findDeliveryOrder(c, deliveryOrderID)
+		if err != nil {
+			return err
+		}
+		if !deliveryorder.CanTransition(order.Status, "shipped") {
+			return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Delivery order cannot be shipped from %s", order.Status))
+		}
+
+		memberID, err := utils.GetUserIDFromCtx(c)
+		if err != nil {
+			return err
+		}
+
+		deliveries := make([]*basepaper.DeliverBasePaperResponse, 0)
+
+		for _, line := range order.Lines {
+			spec := model.BasePaper{
+				Storage:        order.Storage,
+				Gsm:            line.Gsm,
+				Width:          line.Width,
+				Io:             line.Io,
+				MaterialNumber: line.MaterialNumber,
+			}
+
+			basePapers, err := s.basePaperRepo.FindInListBySpec(c, &spec)
+			if err != nil {
+				return err
+			}
+
+			reservations, err := s.reservationRepo.FindActiveBySpec(c, &spec, time.Now().Unix())
+			if err != nil {
+				return err
+			}
+			if line.Quantity > reservation.Pool(basePapers, reservations) {
+				return app.NewError(nil, app.EBadRequest, fmt.Sprintf(
+					"Not enough unreserved stock for gsm %d, width %d, io %d, material number %d",
+					line.Gsm, line.Width, line.Io, line.MaterialNumber,
+				))
+			}
+
+			allocations, short := deliveryorder.Allocate(basePapers, line.Quantity, func(bp *model.BasePaper) int64 {
+				return reservation.Unreserved(bp, reservations)
+			})
+			if short > 0 {
+				return app.NewError(nil, app.EBadRequest, fmt.Sprintf(
+					"Not enough stock for gsm %d, width %d, io %d, material number %d",
+					line.Gsm, line.Width, line.Io, line.MaterialNumber,
+				))
+			}
+
+			for _, allocation := range allocations {
+				delivery, err := s.basePaperService.Deliver(c, &basepaper.DeliverBasePaperRequest{
+					ID:              allocation.BasePaperID,
+					Quantity:        allocation.Quantity,
+					MemberID:        memberID,
+					DeliveryOrderID: order.ID,
+				})
+				if err != nil {
+					return err
+				}
+
+				deliveries = append(deliveries, delivery)
+			}
+		}
+
+		now := time.Now().Unix()
+
+		order.Status = "shipped"
+		order.ShippedAt = db.NewNullInt(now, true)
+		order.UpdatedAt = now
+
+		err = s.deliveryOrderRepo.Update(c, order)
+		if err != nil {
+			return err
+		}
+
+		res = toDeliveryOrderResponse(order)
+		res.Deliveries = deliveries
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *service) transition(ctx context.Context, deliveryOrderID int64, status string) (*deliveryorder.DeliveryOrderResponse, error) {
+	var order *model.DeliveryOrder
+
+	err := s.deliveryOrderRepo.WithTransaction(ctx, func(c context.Context) error {
+		var err error
+		order, err = s.findDeliveryOrder(c, deliveryOrderID)
+		if err != nil {
+			return err
+		}
+		if !deliveryorder.CanTransition(order.Status, status) {
+			return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Delivery order cannot move from %s to %s", order.Status, status))
+		}
+
+		order.Status = status
+		order.UpdatedAt = time.Now().Unix()
+
+		return s.deliveryOrderRepo.Update(c, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDeliveryOrderResponse(order), nil
+}
+
+func (s *service) findDeliveryOrder(ctx context.Context, deliveryOrderID int64) (*model.DeliveryOrder, error) {
+	order, err := s.deliveryOrderRepo.FindByID(ctx, deliveryOrderID)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil, app.NewError(nil, app.ENotFound, "Delivery order not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, order.Storage.ID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func toLines(lines []deliveryorder.Line) []*model.DeliveryOrderLine {
+	res := make([]*model.DeliveryOrderLine, 0)
+	for _, line := range lines {
+		res = append(res, &model.DeliveryOrderLine{
+			Gsm:            line.Gsm,
+			Width:          line.Width,
+			Io:             line.Io,
+			MaterialNumber: line.MaterialNumber,
+			Quantity:       line.Quantity,
+		})
+	}
+
+	return res
+}
+
+func toDeliveryOrderResponse(order *model.DeliveryOrder) *deliveryorder.DeliveryOrderResponse {
+	lines := make([]*deliveryorder.LineResponse, 0)
+	for _, line := range order.Lines {
+		lines = append(lines, &deliveryorder.LineResponse{
+			ID:             line.ID,
+			Gsm:            line.Gsm,
+			Width:          line.Width,
+			Io:             line.Io,
+			MaterialNumber: line.MaterialNumber,
+			Quantity:       line.Quantity,
+		})
+	}
+
+	return &deliveryorder.DeliveryOrderResponse{
+		ID:        order.ID,
+		StorageID: order.Storage.ID,
+		Number:    order.Number,
+		Customer:  order.Customer,
+		Status:    order.Status,
+		MemberID:  order.Member.ID,
+		Lines:     lines,
+		ShippedAt: order.ShippedAt.Int64,
+		CreatedAt: order.CreatedAt,
+		UpdatedAt: order.UpdatedAt,
+	}
+}
@@ -0,0 +1,65 @@
+package deliveryorder
+
+import (
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/pkg/basepaper"
+	"github.com/go-playground/validator/v10"
+)
+
+type Line struct {
+	Gsm            int64 `json:"gsm" validate:"required"`
+	Width          int64 `json:"width" validate:"required"`
+	Io             int64 `json:"io" validate:"required"`
+	MaterialNumber int64 `json:"materialNumber" validate:"required"`
+	Quantity       int64 `json:"quantity" validate:"required,gt=0"`
+}
+
+type CreateDeliveryOrderRequest struct {
+	StorageID int64  `json:"storageID" validate:"required"`
+	Number    string `json:"number" validate:"required,lte=50"`
+	Customer  string `json:"customer" validate:"required,lte=255"`
+	Lines     []Line `json:"lines" validate:"required,min=1,dive"`
+}
+
+func (r *CreateDeliveryOrderRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type UpdateDeliveryOrderRequest struct {
+	ID       int64  `json:"id" validate:"required"`
+	Customer string `json:"customer" validate:"required,lte=255"`
+	Lines    []Line `json:"lines" validate:"required,min=1,dive"`
+}
+
+func (r *UpdateDeliveryOrderRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type LineResponse struct {
+	ID             int64 `json:"id"`
+	Gsm            int64 `json:"gsm"`
+	Width          int64 `json:"width"`
+	Io             int64 `json:"io"`
+	MaterialNumber int64 `json:"materialNumber"`
+	Quantity       int64 `json:"quantity"`
+}
+
+type DeliveryOrderResponse struct {
+	ID         int64                                 `json:"id"`
+	StorageID  int64                                 `json:"storageID"`
+	Number     string                                `json:"number"`
+	Customer   string                                `json:"customer"`
+	Status     string                                `json:"status"`
+	MemberID   int64                                 `json:"memberID"`
+	Lines      []*LineResponse                       `json:"lines"`
+	Deliveries []*basepaper.DeliverBasePaperResponse `json:"deliveries,omitempty"`
+	ShippedAt  int64                                 `json:"shippedAt,omitempty"`
+	CreatedAt  int64                                 `json:"createdAt"`
+	UpdatedAt  int64                                 `json:"updatedAt"`
+}
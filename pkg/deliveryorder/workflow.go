@@ -0,0 +1,46 @@
+package deliveryorder
+
+import "github.com/bagus2x/tjiwi/pkg/model"
+
+var transitions = map[string][]string{
+	"draft":   {"picking", "cancelled"},
+	"picking": {"shipped", "cancelled"},
+}
+
+func CanTransition(from, to string) bool {
+	for _, status := range transitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Allocation struct {
+	BasePaperID int64
+	Quantity    int64
+}
+
+func Allocate(basePapers []*model.BasePaper, quantity int64, available func(*model.BasePaper) int64) ([]*Allocation, int64) {
+	allocations := make([]*Allocation, 0)
+
+	for _, bp := range basePapers {
+		if quantity == 0 {
+			break
+		}
+
+		taken := available(bp)
+		if taken <= 0 {
+			continue
+		}
+		if taken > quantity {
+			taken = quantity
+		}
+
+		allocations = append(allocations, &Allocation{BasePaperID: bp.ID, Quantity: taken})
+		quantity -= taken
+	}
+
+	return allocations, quantity
+}
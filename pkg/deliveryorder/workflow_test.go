@@ -0,0 +1,59 @@
+package deliveryorder
+
+import (
+	"testing"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransition(t *testing.T) {
+	assert.True(t, CanTransition("draft", "picking"))
+	assert.True(t, CanTransition("draft", "cancelled"))
+	assert.True(t, CanTransition("picking", "shipped"))
+	assert.True(t, CanTransition("picking", "cancelled"))
+
+	assert.False(t, CanTransition("draft", "shipped"))
+	assert.False(t, CanTransition("shipped", "cancelled"))
+	assert.False(t, CanTransition("cancelled", "draft"))
+}
+
+func quantityOf(bp *model.BasePaper) int64 {
+	return bp.Quantity
+}
+
+func TestAllocate(t *testing.T) {
+	basePapers := []*model.BasePaper{
+		{ID: 1, Quantity: 3},
+		{ID: 2, Quantity: 0},
+		{ID: 3, Quantity: 5},
+		{ID: 4, Quantity: 2},
+	}
+
+	t.Run("spread over rows", func(t *testing.T) {
+		allocations, short := Allocate(basePapers, 6, quantityOf)
+		assert.Equal(t, int64(0), short)
+		assert.Equal(t, []*Allocation{
+			{BasePaperID: 1, Quantity: 3},
+			{BasePaperID: 3, Quantity: 3},
+		}, allocations)
+	})
+
+	t.Run("not enough stock", func(t *testing.T) {
+		allocations, short := Allocate(basePapers, 12, quantityOf)
+		assert.Equal(t, int64(2), short)
+		assert.Len(t, allocations, 3)
+	})
+
+	t.Run("skip reserved stock", func(t *testing.T) {
+		reserved := map[int64]int64{1: 3, 3: 1}
+		allocations, short := Allocate(basePapers, 6, func(bp *model.BasePaper) int64 {
+			return bp.Quantity - reserved[bp.ID]
+		})
+		assert.Equal(t, int64(0), short)
+		assert.Equal(t, []*Allocation{
+			{BasePaperID: 3, Quantity: 4},
+			{BasePaperID: 4, Quantity: 2},
+		}, allocations)
+	})
+}
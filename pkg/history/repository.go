@@ -8,5 +8,7 @@ import (
 
 type Repository interface {
 	Create(ctx context.Context, history *model.History) error
+	FindByID(ctx context.Context, historyID int64) (*model.History, error)
+	SumAffectedByReference(ctx context.Context, referenceID int64, status string) (int64, error)
 	Filter(ctx context.Context, params *Params) ([]*model.History, *Cursor, error)
 }
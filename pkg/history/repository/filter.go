@@ -18,8 +18,9 @@ func descendingFilter(params *history.Params) (string, []interface{}) {
 		columns.WriteString(`
 			WITH prev_mode AS (
 				SELECT
-					h.id as history_id, bp.id, bp.gsm, bp.width, bp.io, bp.material_number, bp.quantity, bp.location, h.storage_id, 
-					p.id, p.photo, p.username, h.status, h.affected, h.created_at
+					h.id as history_id, bp.id, bp.gsm, bp.width, bp.io, bp.material_number, bp.quantity, bp.weight, bp.location, h.storage_id, 
+					p.id, p.photo, p.username, h.status, h.affected, h.affected_weight, h.from_location, h.to_location, h.reason, h.note, h.reference_id,
+					h.delivery_order_id, h.purchase_order_line_id, h.created_at
 				FROM
 					History h
 				JOIN
@@ -42,8 +43,9 @@ func descendingFilter(params *history.Params) (string, []interface{}) {
 	} else {
 		columns.WriteString(`
 			SELECT
-				h.id, bp.id, bp.gsm, bp.width, bp.io, bp.material_number, bp.quantity, bp.location, h.storage_id, 
-				p.id, p.photo, p.username, h.status, h.affected, h.created_at
+				h.id, bp.id, bp.gsm, bp.width, bp.io, bp.material_number, bp.quantity, bp.weight, bp.location, h.storage_id, 
+				p.id, p.photo, p.username, h.status, h.affected, h.affected_weight, h.from_location, h.to_location, h.reason, h.note, h.reference_id,
+				h.delivery_order_id, h.purchase_order_line_id, h.created_at
 			FROM
 				History h
 			JOIN
@@ -78,6 +80,10 @@ func descendingFilter(params *history.Params) (string, []interface{}) {
 		stringIndex++
 	}
 
+	if params.DeliveryOrderID != 0 {
+		fmt.Fprintf(&columns, " AND h.delivery_order_id = %d ", params.DeliveryOrderID)
+	}
+
 	if params.StartDate != 0 {
 		fmt.Fprintf(&columns, " AND h.created_at >= %d ", params.StartDate)
 	}
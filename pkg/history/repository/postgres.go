@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/bagus2x/tjiwi/app"
 	"github.com/bagus2x/tjiwi/db"
 	"github.com/bagus2x/tjiwi/pkg/history"
 	"github.com/bagus2x/tjiwi/pkg/model"
@@ -29,9 +30,10 @@ func (r *repository) Create(ctx context.Context, history *model.History) error {
 	query := `
 			INSERT INTO
 				History
-				(base_paper_id, storage_id, member_id, status, affected, created_at)
+				(base_paper_id, storage_id, member_id, status, affected, affected_weight, from_location, to_location, reason, note,
+				reference_id, delivery_order_id, purchase_order_line_id, created_at)
 			VALUES
-				($1, $2, $3, $4, $5, $6)
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 			RETURNING
 				id
 	`
@@ -44,12 +46,99 @@ func (r *repository) Create(ctx context.Context, history *model.History) error {
 		history.Member.ID,
 		history.Status,
 		history.Affected,
+		history.AffectedWeight,
+		history.FromLocation,
+		history.ToLocation,
+		history.Reason,
+		history.Note,
+		history.Reference,
+		history.DeliveryOrder,
+		history.PurchaseOrderLine,
 		history.CreatedAt,
 	).Scan(&history.ID)
 
 	return err
 }
 
+func (r *repository) FindByID(ctx context.Context, historyID int64) (*model.History, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				h.id, bp.id, bp.gsm, bp.width, bp.io, bp.material_number, bp.quantity, bp.weight, bp.location, h.storage_id,
+				h.member_id, h.status, h.affected, h.affected_weight, h.from_location, h.to_location, h.reason, h.note, h.reference_id, h.delivery_order_id,
+				h.purchase_order_line_id, h.created_at
+			FROM
+				History h
+			JOIN
+				Base_Paper bp
+			ON
+				h.base_paper_id = bp.id
+			WHERE
+				h.id = $1
+			FOR UPDATE OF
+				h
+	`
+
+	var history model.History
+
+	err := tx.QueryRowContext(ctx, query, historyID).Scan(
+		&history.ID,
+		&history.BasePaper.ID,
+		&history.BasePaper.Gsm,
+		&history.BasePaper.Width,
+		&history.BasePaper.Io,
+		&history.BasePaper.MaterialNumber,
+		&history.BasePaper.Quantity,
+		&history.BasePaper.Weight,
+		&history.BasePaper.Location,
+		&history.Storage.ID,
+		&history.Member.ID,
+		&history.Status,
+		&history.Affected,
+		&history.AffectedWeight,
+		&history.FromLocation,
+		&history.ToLocation,
+		&history.Reason,
+		&history.Note,
+		&history.Reference,
+		&history.DeliveryOrder,
+		&history.PurchaseOrderLine,
+		&history.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, app.NewError(err, app.ENotFound)
+		}
+		return nil, err
+	}
+
+	history.BasePaper.Storage.ID = history.Storage.ID
+
+	return &history, nil
+}
+
+func (r *repository) SumAffectedByReference(ctx context.Context, referenceID int64, status string) (int64, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				COALESCE(SUM(affected), 0)
+			FROM
+				History
+			WHERE
+				reference_id = $1 AND status = $2 AND id NOT IN (
+					SELECT reference_id FROM History WHERE status = 'reversed' AND reference_id IS NOT NULL
+				)
+	`
+
+	var sum int64
+
+	err := tx.QueryRowContext(ctx, query, referenceID, status).Scan(&sum)
+
+	return sum, err
+}
+
 func (r *repository) Filter(ctx context.Context, params *history.Params) ([]*model.History, *history.Cursor, error) {
 	query, values := descendingFilter(params)
 
@@ -73,6 +162,7 @@ func (r *repository) Filter(ctx context.Context, params *history.Params) ([]*mod
 			&history.BasePaper.Io,
 			&history.BasePaper.MaterialNumber,
 			&history.BasePaper.Quantity,
+			&history.BasePaper.Weight,
 			&history.BasePaper.Location,
 			&history.Storage.ID,
 			&history.Member.ID,
@@ -80,6 +170,14 @@ func (r *repository) Filter(ctx context.Context, params *history.Params) ([]*mod
 			&history.Member.Username,
 			&history.Status,
 			&history.Affected,
+			&history.AffectedWeight,
+			&history.FromLocation,
+			&history.ToLocation,
+			&history.Reason,
+			&history.Note,
+			&history.Reference,
+			&history.DeliveryOrder,
+			&history.PurchaseOrderLine,
 			&history.CreatedAt,
 		)
 		if err != nil {
@@ -2,17 +2,25 @@ package service
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/bagus2x/tjiwi/pkg/history"
+	"github.com/bagus2x/tjiwi/pkg/material"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/roll"
 )
 
 type service struct {
-	historyRepo history.Repository
+	historyRepo  history.Repository
+	materialRepo material.Repository
+	rollRepo     roll.Repository
 }
 
-func New(historyRepo history.Repository) history.Service {
+func New(historyRepo history.Repository, materialRepo material.Repository, rollRepo roll.Repository) history.Service {
 	return &service{
-		historyRepo: historyRepo,
+		historyRepo:  historyRepo,
+		materialRepo: materialRepo,
+		rollRepo:     rollRepo,
 	}
 }
 
@@ -22,6 +30,21 @@ func (s *service) Filter(ctx context.Context, params *history.Params) (*history.
 		return nil, err
 	}
 
+	descriptions, err := s.materialDescriptions(ctx, histories)
+	if err != nil {
+		return nil, err
+	}
+
+	historyIDs := make([]int64, 0, len(histories))
+	for _, h := range histories {
+		historyIDs = append(historyIDs, h.ID)
+	}
+
+	serials, err := s.rollRepo.FindSerialsByHistoryIDs(ctx, historyIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	var res history.GetHistoriesResponse
 	res.Cursor = *cursor
 	res.Histories = make([]*history.GetHistoryResponse, 0)
@@ -31,24 +54,65 @@ func (s *service) Filter(ctx context.Context, params *history.Params) (*history.
 			ID:        h.ID,
 			StorageID: h.Storage.ID,
 			BasePaper: history.BasePaper{
-				ID:             h.BasePaper.ID,
-				Gsm:            h.BasePaper.Gsm,
-				Width:          h.BasePaper.Width,
-				Io:             h.BasePaper.Io,
-				MaterialNumber: h.BasePaper.MaterialNumber,
-				Quantity:       h.BasePaper.Quantity,
-				Location:       h.BasePaper.Location,
+				ID:                  h.BasePaper.ID,
+				Gsm:                 h.BasePaper.Gsm,
+				Width:               h.BasePaper.Width,
+				Io:                  h.BasePaper.Io,
+				MaterialNumber:      h.BasePaper.MaterialNumber,
+				MaterialDescription: descriptions[h.Storage.ID][h.BasePaper.MaterialNumber],
+				Quantity:            h.BasePaper.Quantity,
+				Weight:              h.BasePaper.Weight,
+				Location:            h.BasePaper.Location,
 			},
 			Member: history.Member{
 				ID:       h.Member.ID,
 				Photo:    h.Member.Photo.String,
 				Username: h.Member.Username,
 			},
-			Status:    h.Status,
-			Affected:  h.Affected,
-			CreatedAt: h.CreatedAt,
+			Status:              h.Status,
+			Affected:            h.Affected,
+			AffectedWeight:      h.AffectedWeight,
+			Rolls:               serials[h.ID],
+			FromLocation:        nullableLocation(h.FromLocation),
+			ToLocation:          nullableLocation(h.ToLocation),
+			Reason:              h.Reason.String,
+			Note:                h.Note.String,
+			Reference:           h.Reference.Int64,
+			DeliveryOrderID:     h.DeliveryOrder.Int64,
+			PurchaseOrderLineID: h.PurchaseOrderLine.Int64,
+			CreatedAt:           h.CreatedAt,
 		})
 	}
 
 	return &res, nil
 }
+
+func (s *service) materialDescriptions(ctx context.Context, histories []*model.History) (map[int64]map[int64]string, error) {
+	numbers := make(map[int64][]int64)
+	for _, h := range histories {
+		numbers[h.Storage.ID] = append(numbers[h.Storage.ID], h.BasePaper.MaterialNumber)
+	}
+
+	descriptions := make(map[int64]map[int64]string)
+	for storageID, storageNumbers := range numbers {
+		materials, err := s.materialRepo.FindByNumbers(ctx, storageID, storageNumbers)
+		if err != nil {
+			return nil, err
+		}
+
+		descriptions[storageID] = make(map[int64]string)
+		for _, m := range materials {
+			descriptions[storageID][m.Number] = m.Description
+		}
+	}
+
+	return descriptions, nil
+}
+
+func nullableLocation(location sql.NullString) *string {
+	if !location.Valid {
+		return nil
+	}
+
+	return &location.String
+}
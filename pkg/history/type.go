@@ -1,13 +1,14 @@
 package history
 
 type Params struct {
-	StorageID int64  `form:"storage_id"`
-	Status    string `form:"status"`
-	StartDate int64  `form:"start"`
-	EndDate   int64  `form:"end"`
-	Cursor    int64  `form:"cursor"`
-	Direction string `form:"dir"`
-	Limit     int64  `form:"limit"`
+	StorageID       int64  `form:"storage_id"`
+	Status          string `form:"status"`
+	DeliveryOrderID int64  `form:"delivery_order_id"`
+	StartDate       int64  `form:"start"`
+	EndDate         int64  `form:"end"`
+	Cursor          int64  `form:"cursor"`
+	Direction       string `form:"dir"`
+	Limit           int64  `form:"limit"`
 }
 
 type Cursor struct {
@@ -16,13 +17,15 @@ type Cursor struct {
 }
 
 type BasePaper struct {
-	ID             int64  `json:"id"`
-	Gsm            int64  `json:"gsm"`
-	Width          int64  `json:"width"`
-	Io             int64  `json:"io"`
-	MaterialNumber int64  `json:"materialNumber"`
-	Quantity       int64  `json:"quantity"`
-	Location       string `json:"location"`
+	ID                  int64   `json:"id"`
+	Gsm                 int64   `json:"gsm"`
+	Width               int64   `json:"width"`
+	Io                  int64   `json:"io"`
+	MaterialNumber      int64   `json:"materialNumber"`
+	MaterialDescription string  `json:"materialDescription"`
+	Quantity            int64   `json:"quantity"`
+	Weight              float64 `json:"weight"`
+	Location            string  `json:"location"`
 }
 
 type Member struct {
@@ -32,14 +35,23 @@ type Member struct {
 }
 
 type GetHistoryResponse struct {
-	ID        int64     `json:"id"`
-	StorageID int64     `json:"storageID"`
-	BasePaper BasePaper `json:"basePaper"`
-	Member    Member    `json:"member"`
-	Status    string    `json:"status"`
-	Affected  int64     `json:"affected"`
-	Location  string    `json:"location"`
-	CreatedAt int64     `json:"createdAt"`
+	ID                  int64     `json:"id"`
+	StorageID           int64     `json:"storageID"`
+	BasePaper           BasePaper `json:"basePaper"`
+	Member              Member    `json:"member"`
+	Status              string    `json:"status"`
+	Affected            int64     `json:"affected"`
+	AffectedWeight      float64   `json:"affectedWeight"`
+	Rolls               []string  `json:"rolls,omitempty"`
+	FromLocation        *string   `json:"fromLocation"`
+	ToLocation          *string   `json:"toLocation"`
+	Reason              string    `json:"reason,omitempty"`
+	Note                string    `json:"note,omitempty"`
+	Reference           int64     `json:"reference,omitempty"`
+	DeliveryOrderID     int64     `json:"deliveryOrderID,omitempty"`
+	PurchaseOrderLineID int64     `json:"purchaseOrderLineID,omitempty"`
+	Location            string    `json:"location"`
+	CreatedAt           int64     `json:"createdAt"`
 }
 
 type GetHistoriesResponse struct {
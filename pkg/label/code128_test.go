@@ -0,0 +1,46 @@
+package label
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCode128Patterns(t *testing.T) {
+	assert.Len(t, code128Patterns, 107)
+
+	for value, pattern := range code128Patterns {
+		sum := 0
+		for _, width := range pattern {
+			sum += int(width - '0')
+		}
+
+		if value == code128Stop {
+			assert.Equal(t, 13, sum)
+		} else {
+			assert.Equal(t, 11, sum, "pattern %d", value)
+		}
+	}
+}
+
+func TestCode128Values(t *testing.T) {
+	values, err := Code128Values("AB")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{104, 33, 34, 102, 106}, values)
+
+	_, err = Code128Values("é")
+	assert.Error(t, err)
+}
+
+func TestCode128(t *testing.T) {
+	widths, err := Code128("TJW:BP:1")
+	assert.NoError(t, err)
+
+	sum := 0
+	for _, width := range widths {
+		sum += width
+	}
+
+	assert.Equal(t, 11*(len("TJW:BP:1")+3)+2, sum)
+	assert.Len(t, widths, 6*(len("TJW:BP:1")+2)+7)
+}
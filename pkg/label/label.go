@@ -0,0 +1,111 @@
+package label
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+const (
+	FormatPDF = "pdf"
+	FormatZPL = "zpl"
+)
+
+type Label struct {
+	Code  string
+	Title string
+	Lines []string
+}
+
+func BasePaperCode(basePaperID int64) string {
+	return fmt.Sprintf("TJW:BP:%d", basePaperID)
+}
+
+func RollCode(storageID int64, serial string) string {
+	return fmt.Sprintf("TJW:RL:%d:%s", storageID, serial)
+}
+
+func LocationCode(locationID int64) string {
+	return fmt.Sprintf("TJW:LC:%d", locationID)
+}
+
+func ForBasePaper(bp *model.BasePaper, description string) *Label {
+	location := bp.Location
+	if location == "" {
+		location = "Buffer area"
+	}
+
+	return &Label{
+		Code:  BasePaperCode(bp.ID),
+		Title: spec(bp),
+		Lines: []string{
+			material(bp, description),
+			fmt.Sprintf("Location %s", location),
+			fmt.Sprintf("%d rolls, %s kg", bp.Quantity, strconv.FormatFloat(bp.Weight, 'f', -1, 64)),
+		},
+	}
+}
+
+func ForRoll(rl *model.Roll, bp *model.BasePaper, description string) *Label {
+	lines := []string{spec(bp), material(bp, description)}
+	if rl.Weight > 0 {
+		lines = append(lines, fmt.Sprintf("%s kg", strconv.FormatFloat(rl.Weight, 'f', -1, 64)))
+	}
+
+	return &Label{
+		Code:  RollCode(rl.Storage.ID, rl.Serial),
+		Title: rl.Serial,
+		Lines: lines,
+	}
+}
+
+func ForLocation(loc *model.Location) *Label {
+	lines := []string{fmt.Sprintf("Zone %s / Rack %s / Bin %s", loc.Zone, loc.Rack, loc.Bin)}
+	if loc.Capacity.Valid {
+		lines = append(lines, fmt.Sprintf("Capacity %d rolls", loc.Capacity.Int64))
+	}
+
+	return &Label{
+		Code:  LocationCode(loc.ID),
+		Title: loc.Code,
+		Lines: lines,
+	}
+}
+
+func Write(format string, w io.Writer, labels []*Label) error {
+	switch format {
+	case FormatPDF:
+		return WritePDF(w, labels)
+	case FormatZPL:
+		return WriteZPL(w, labels)
+	}
+
+	return app.NewError(nil, app.EBadRequest, "Label format must be pdf or zpl")
+}
+
+func ValidFormat(format string) bool {
+	return format == FormatPDF || format == FormatZPL
+}
+
+func ContentType(format string) string {
+	if format == FormatZPL {
+		return "text/plain; charset=utf-8"
+	}
+
+	return "application/pdf"
+}
+
+func spec(bp *model.BasePaper) string {
+	return fmt.Sprintf("GSM %d / W %d / IO %d", bp.Gsm, bp.Width, bp.Io)
+}
+
+func material(bp *model.BasePaper, description string) string {
+	if description == "" {
+		return fmt.Sprintf("Material %d", bp.MaterialNumber)
+	}
+
+	return fmt.Sprintf("Material %d - %s", bp.MaterialNumber, description)
+}
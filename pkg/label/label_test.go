@@ -0,0 +1,42 @@
+package label
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForBasePaper(t *testing.T) {
+	bp := model.BasePaper{ID: 7, Gsm: 80, Width: 1200, Io: 3, MaterialNumber: 4711, Quantity: 2, Weight: 1900.5}
+
+	l := ForBasePaper(&bp, "Kraft liner")
+	assert.Equal(t, "TJW:BP:7", l.Code)
+	assert.Equal(t, "GSM 80 / W 1200 / IO 3", l.Title)
+	assert.Equal(t, []string{"Material 4711 - Kraft liner", "Location Buffer area", "2 rolls, 1900.5 kg"}, l.Lines)
+}
+
+func TestForRoll(t *testing.T) {
+	bp := model.BasePaper{ID: 7, Gsm: 80, Width: 1200, Io: 3, MaterialNumber: 4711}
+	rl := model.Roll{Storage: model.Storage{ID: 2}, Serial: "R-001", Weight: 950.25}
+
+	l := ForRoll(&rl, &bp, "")
+	assert.Equal(t, "TJW:RL:2:R-001", l.Code)
+	assert.Equal(t, "R-001", l.Title)
+	assert.Equal(t, []string{"GSM 80 / W 1200 / IO 3", "Material 4711", "950.25 kg"}, l.Lines)
+}
+
+func TestForLocation(t *testing.T) {
+	loc := model.Location{ID: 3, Zone: "A", Rack: "01", Bin: "02", Code: "A-01-02", Capacity: sql.NullInt64{Int64: 40, Valid: true}}
+
+	l := ForLocation(&loc)
+	assert.Equal(t, "TJW:LC:3", l.Code)
+	assert.Equal(t, "A-01-02", l.Title)
+	assert.Equal(t, []string{"Zone A / Rack 01 / Bin 02", "Capacity 40 rolls"}, l.Lines)
+}
+
+func TestWriteInvalidFormat(t *testing.T) {
+	assert.Error(t, Write("png", nil, nil))
+	assert.False(t, ValidFormat("png"))
+}
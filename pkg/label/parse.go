@@ -0,0 +1,58 @@
+package label
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/bagus2x/tjiwi/app"
+)
+
+const (
+	KindBasePaper = "basepaper"
+	KindRoll      = "roll"
+	KindLocation  = "location"
+)
+
+type Code struct {
+	Kind      string
+	ID        int64
+	StorageID int64
+	Serial    string
+}
+
+func Parse(code string) (*Code, error) {
+	parts := strings.SplitN(strings.TrimSpace(code), ":", 4)
+	if len(parts) < 3 || parts[0] != "TJW" {
+		return nil, app.NewError(nil, app.EBadRequest, "Unrecognized label code")
+	}
+
+	switch parts[1] {
+	case "BP", "LC":
+		if len(parts) != 3 {
+			return nil, app.NewError(nil, app.EBadRequest, "Unrecognized label code")
+		}
+
+		id, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil || id <= 0 {
+			return nil, app.NewError(nil, app.EBadRequest, "Unrecognized label code")
+		}
+
+		if parts[1] == "BP" {
+			return &Code{Kind: KindBasePaper, ID: id}, nil
+		}
+		return &Code{Kind: KindLocation, ID: id}, nil
+	case "RL":
+		if len(parts) != 4 || parts[3] == "" {
+			return nil, app.NewError(nil, app.EBadRequest, "Unrecognized label code")
+		}
+
+		storageID, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil || storageID <= 0 {
+			return nil, app.NewError(nil, app.EBadRequest, "Unrecognized label code")
+		}
+
+		return &Code{Kind: KindRoll, StorageID: storageID, Serial: parts[3]}, nil
+	}
+
+	return nil, app.NewError(nil, app.EBadRequest, "Unrecognized label code")
+}
@@ -0,0 +1,26 @@
+package label
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	code, err := Parse(BasePaperCode(12))
+	assert.NoError(t, err)
+	assert.Equal(t, &Code{Kind: KindBasePaper, ID: 12}, code)
+
+	code, err = Parse(" " + LocationCode(3) + "\n")
+	assert.NoError(t, err)
+	assert.Equal(t, &Code{Kind: KindLocation, ID: 3}, code)
+
+	code, err = Parse(RollCode(2, "R:001"))
+	assert.NoError(t, err)
+	assert.Equal(t, &Code{Kind: KindRoll, StorageID: 2, Serial: "R:001"}, code)
+
+	for _, invalid := range []string{"", "4711", "TJW:BP", "TJW:BP:abc", "TJW:BP:0", "TJW:BP:1:2", "TJW:RL:2", "TJW:RL:x:R1", "TJW:XX:1", "ABC:BP:1"} {
+		_, err = Parse(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,127 @@
+package label
+
+import (
+	"io"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	pageWidth     = 595.28
+	pageHeight    = 841.89
+	pageMargin    = 28.35
+	labelColumns  = 2
+	labelRows     = 5
+	labelPadding  = 10.0
+	barcodeHeight = 40.0
+	qrSide        = 72.0
+)
+
+func WritePDF(w io.Writer, labels []*Label) error {
+	perPage := labelColumns * labelRows
+
+	pdf := fpdf.New("P", "pt", "A4", "")
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetDrawColor(204, 204, 204)
+	pdf.SetFillColor(0, 0, 0)
+
+	if len(labels) == 0 {
+		pdf.AddPage()
+	}
+
+	cellWidth := (pageWidth - 2*pageMargin) / labelColumns
+	cellHeight := (pageHeight - 2*pageMargin) / labelRows
+
+	for i, l := range labels {
+		if i%perPage == 0 {
+			pdf.AddPage()
+		}
+
+		x := pageMargin + float64(i%labelColumns)*cellWidth
+		top := pageMargin + float64(i%perPage/labelColumns)*cellHeight
+
+		err := drawLabel(pdf, l, x, top, cellWidth, cellHeight)
+		if err != nil {
+			return err
+		}
+	}
+
+	return pdf.Output(w)
+}
+
+func drawLabel(pdf *fpdf.Fpdf, l *Label, x, top, cellWidth, cellHeight float64) error {
+	widths, err := Code128(l.Code)
+	if err != nil {
+		return err
+	}
+
+	qr, err := QR(l.Code)
+	if err != nil {
+		return err
+	}
+
+	pdf.Rect(x, top, cellWidth, cellHeight, "D")
+
+	y := top + labelPadding + 14
+	pdf.SetFont("Helvetica", "", 14)
+	pdf.Text(x+labelPadding, y, ascii(l.Title))
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, line := range l.Lines {
+		y += 13
+		pdf.Text(x+labelPadding, y, ascii(line))
+	}
+
+	modules := 0
+	for _, width := range widths {
+		modules += width
+	}
+
+	module := (cellWidth - 2*labelPadding) / float64(modules)
+	if module > 1.2 {
+		module = 1.2
+	}
+
+	barX := x + labelPadding
+	barY := top + cellHeight - labelPadding - 12 - barcodeHeight
+	for j, width := range widths {
+		if j%2 == 0 {
+			pdf.Rect(barX, barY, module*float64(width), barcodeHeight, "F")
+		}
+		barX += module * float64(width)
+	}
+
+	pdf.SetFont("Helvetica", "", 8)
+	pdf.Text(x+labelPadding, top+cellHeight-labelPadding, ascii(l.Code))
+
+	module = qrSide / float64(len(qr))
+	qrX := x + cellWidth - labelPadding - qrSide
+	qrY := top + labelPadding
+	for row, modules := range qr {
+		for col := 0; col < len(modules); col++ {
+			if !modules[col] {
+				continue
+			}
+
+			run := col
+			for run < len(modules) && modules[run] {
+				run++
+			}
+
+			pdf.Rect(qrX+module*float64(col), qrY+module*float64(row), module*float64(run-col), module, "F")
+			col = run
+		}
+	}
+
+	return pdf.Error()
+}
+
+func ascii(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 32 || r > 126 {
+			return '?'
+		}
+		return r
+	}, text)
+}
@@ -0,0 +1,35 @@
+package label
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWritePDF(t *testing.T) {
+	labels := make([]*Label, 0)
+	for i := 0; i < 11; i++ {
+		labels = append(labels, &Label{Code: LocationCode(int64(i)), Title: "A-01-(1)", Lines: []string{"Zone A"}})
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, WritePDF(&buf, labels))
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "%PDF-"))
+	assert.True(t, strings.HasSuffix(strings.TrimSpace(out), "%%EOF"))
+	assert.Contains(t, out, "/Count 2")
+}
+
+func TestWritePDFEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WritePDF(&buf, nil))
+	assert.Contains(t, buf.String(), "/Count 1")
+}
+
+func TestWritePDFInvalidCode(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Error(t, WritePDF(&buf, []*Label{{Code: "TJW:RL:1:é"}}))
+}
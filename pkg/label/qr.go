@@ -0,0 +1,22 @@
+package label
+
+import (
+	"github.com/bagus2x/tjiwi/app"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const qrMaxLength = 180
+
+func QR(data string) ([][]bool, error) {
+	if len(data) > qrMaxLength {
+		return nil, app.NewError(nil, app.EBadRequest, "Code is too long for a QR code")
+	}
+
+	q, err := qrcode.New(data, qrcode.Medium)
+	if err != nil {
+		return nil, app.NewError(err, app.EBadRequest, "Code can not be encoded as a QR code")
+	}
+	q.DisableBorder = true
+
+	return q.Bitmap(), nil
+}
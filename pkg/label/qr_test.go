@@ -0,0 +1,37 @@
+package label
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQR(t *testing.T) {
+	modules, err := QR(RollCode(12, "R-0001"))
+	assert.NoError(t, err)
+	assert.Len(t, modules, 21)
+
+	size := len(modules)
+	for _, corner := range [][2]int{{0, 0}, {0, size - 7}, {size - 7, 0}} {
+		for i := 0; i < 7; i++ {
+			assert.True(t, modules[corner[0]][corner[1]+i])
+			assert.True(t, modules[corner[0]+6][corner[1]+i])
+			assert.True(t, modules[corner[0]+i][corner[1]])
+		}
+		assert.False(t, modules[corner[0]+1][corner[1]+1])
+		assert.True(t, modules[corner[0]+3][corner[1]+3])
+	}
+
+	for i := 8; i < size-8; i++ {
+		assert.Equal(t, i%2 == 0, modules[6][i])
+		assert.Equal(t, i%2 == 0, modules[i][6])
+	}
+
+	modules, err = QR(strings.Repeat("x", 120))
+	assert.NoError(t, err)
+	assert.Len(t, modules, 45)
+
+	_, err = QR(strings.Repeat("X", 200))
+	assert.Error(t, err)
+}
@@ -0,0 +1,14 @@
+package label
+
+import (
+	"context"
+	"io"
+)
+
+type Service interface {
+	PrintBasePaper(ctx context.Context, basePaperID int64, format string, w io.Writer) error
+	PrintRolls(ctx context.Context, basePaperID int64, format string, w io.Writer) error
+	PrintRoll(ctx context.Context, storageID int64, serial, format string, w io.Writer) error
+	PrintLocation(ctx context.Context, locationID int64, format string, w io.Writer) error
+	PrintLocations(ctx context.Context, storageID int64, format string, w io.Writer) error
+}
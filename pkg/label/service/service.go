@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/pkg/basepaper"
+	"github.com/bagus2x/tjiwi/pkg/label"
+	"github.com/bagus2x/tjiwi/pkg/location"
+	"github.com/bagus2x/tjiwi/pkg/material"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/roll"
+	stormemb "github.com/bagus2x/tjiwi/pkg/storagemember"
+	"github.com/bagus2x/tjiwi/utils"
+)
+
+type service struct {
+	basePaperRepo basepaper.Repository
+	rollRepo      roll.Repository
+	locationRepo  location.Repository
+	materialRepo  material.Repository
+	storMembRepo  stormemb.Repository
+}
+
+func New(basePaperRepo basepaper.Repository, rollRepo roll.Repository, locationRepo location.Repository, materialRepo material.Repository, storMembRepo stormemb.Repository) label.Service {
+	return &service{
+		basePaperRepo: basePaperRepo,
+		rollRepo:      rollRepo,
+		locationRepo:  locationRepo,
+		materialRepo:  materialRepo,
+		storMembRepo:  storMembRepo,
+	}
+}
+
+func (s *service) PrintBasePaper(ctx context.Context, basePaperID int64, format string, w io.Writer) error {
+	if !label.ValidFormat(format) {
+		return app.NewError(nil, app.EBadRequest, "Label format must be pdf or zpl")
+	}
+
+	bp, err := s.findBasePaper(ctx, basePaperID)
+	if err != nil {
+		return err
+	}
+
+	description, err := s.materialDescription(ctx, bp)
+	if err != nil {
+		return err
+	}
+
+	return label.Write(format, w, []*label.Label{label.ForBasePaper(bp, description)})
+}
+
+func (s *service) PrintRolls(ctx context.Context, basePaperID int64, format string, w io.Writer) error {
+	if !label.ValidFormat(format) {
+		return app.NewError(nil, app.EBadRequest, "Label format must be pdf or zpl")
+	}
+
+	bp, err := s.findBasePaper(ctx, basePaperID)
+	if err != nil {
+		return err
+	}
+
+	rolls, err := s.rollRepo.FindByBasePaperID(ctx, bp.ID)
+	if err != nil {
+		return err
+	}
+	if len(rolls) == 0 {
+		return app.NewError(nil, app.EBadRequest, "Base paper is not tracked per roll")
+	}
+
+	description, err := s.materialDescription(ctx, bp)
+	if err != nil {
+		return err
+	}
+
+	labels := make([]*label.Label, 0, len(rolls))
+	for _, rl := range rolls {
+		labels = append(labels, label.ForRoll(rl, bp, description))
+	}
+
+	return label.Write(format, w, labels)
+}
+
+func (s *service) PrintRoll(ctx context.Context, storageID int64, serial, format string, w io.Writer) error {
+	if !label.ValidFormat(format) {
+		return app.NewError(nil, app.EBadRequest, "Label format must be pdf or zpl")
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, storageID, memberID, false); err != nil {
+		return err
+	}
+
+	rl, err := s.rollRepo.FindBySerial(ctx, storageID, serial)
+	if app.ErrorCode(err) == app.ENotFound {
+		return app.NewError(nil, app.ENotFound, "Roll not found")
+	} else if err != nil {
+		return err
+	}
+
+	bp, err := s.findBasePaper(ctx, rl.BasePaper.ID)
+	if err != nil {
+		return err
+	}
+
+	description, err := s.materialDescription(ctx, bp)
+	if err != nil {
+		return err
+	}
+
+	return label.Write(format, w, []*label.Label{label.ForRoll(rl, bp, description)})
+}
+
+func (s *service) PrintLocation(ctx context.Context, locationID int64, format string, w io.Writer) error {
+	if !label.ValidFormat(format) {
+		return app.NewError(nil, app.EBadRequest, "Label format must be pdf or zpl")
+	}
+
+	loc, err := s.locationRepo.FindByID(ctx, locationID)
+	if app.ErrorCode(err) == app.ENotFound {
+		return app.NewError(nil, app.ENotFound, "Location not found")
+	} else if err != nil {
+		return err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, loc.Storage.ID, memberID, false); err != nil {
+		return err
+	}
+
+	return label.Write(format, w, []*label.Label{label.ForLocation(loc)})
+}
+
+func (s *service) PrintLocations(ctx context.Context, storageID int64, format string, w io.Writer) error {
+	if !label.ValidFormat(format) {
+		return app.NewError(nil, app.EBadRequest, "Label format must be pdf or zpl")
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, storageID, memberID, false); err != nil {
+		return err
+	}
+
+	locations, err := s.locationRepo.FindByStorageID(ctx, storageID)
+	if err != nil {
+		return err
+	}
+
+	labels := make([]*label.Label, 0, len(locations))
+	for _, loc := range locations {
+		if loc.IsActive {
+			labels = append(labels, label.ForLocation(loc))
+		}
+	}
+	if len(labels) == 0 {
+		return app.NewError(nil, app.ENotFound, "Storage has no active locations")
+	}
+
+	return label.Write(format, w, labels)
+}
+
+func (s *service) findBasePaper(ctx context.Context, basePaperID int64) (*model.BasePaper, error) {
+	bp, err := s.basePaperRepo.FindByID(ctx, basePaperID)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil, app.NewError(nil, app.ENotFound, "Base paper not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, bp.Storage.ID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	return bp, nil
+}
+
+func (s *service) materialDescription(ctx context.Context, bp *model.BasePaper) (string, error) {
+	m, err := s.materialRepo.FindByNumber(ctx, bp.Storage.ID, bp.MaterialNumber)
+	if app.ErrorCode(err) == app.ENotFound {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return m.Description, nil
+}
@@ -0,0 +1,31 @@
+package label
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var zplEscaper = strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E")
+
+func WriteZPL(w io.Writer, labels []*Label) error {
+	writer := bufio.NewWriter(w)
+
+	for _, l := range labels {
+		fmt.Fprint(writer, "^XA\n^CI28\n")
+		fmt.Fprintf(writer, "^FO40,30^A0N,40,40^FH^FD%s^FS\n", zplEscaper.Replace(l.Title))
+
+		y := 80
+		for _, line := range l.Lines {
+			fmt.Fprintf(writer, "^FO40,%d^A0N,24,24^FH^FD%s^FS\n", y, zplEscaper.Replace(line))
+			y += 30
+		}
+
+		fmt.Fprintf(writer, "^FO40,%d^BY2^BCN,80,Y,N,N^FH^FD%s^FS\n", y+10, zplEscaper.Replace(l.Code))
+		fmt.Fprintf(writer, "^FO560,30^BQN,2,5^FH^FDQA,%s^FS\n", zplEscaper.Replace(l.Code))
+		fmt.Fprint(writer, "^XZ\n")
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,27 @@
+package label
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteZPL(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteZPL(&buf, []*Label{
+		{Code: "TJW:LC:1", Title: "A-01-01", Lines: []string{"Zone A"}},
+		{Code: "TJW:RL:1:R_01", Title: "R^01", Lines: nil},
+	})
+	assert.NoError(t, err)
+
+	out := buf.String()
+	assert.Equal(t, 2, strings.Count(out, "^XA"))
+	assert.Equal(t, 2, strings.Count(out, "^XZ"))
+	assert.Contains(t, out, "^BCN,80,Y,N,N^FH^FDTJW:LC:1^FS")
+	assert.Contains(t, out, "^FDQA,TJW:LC:1^FS")
+	assert.Contains(t, out, "^FDTJW:RL:1:R_5F01^FS")
+	assert.Contains(t, out, "^FDR_5E01^FS")
+}
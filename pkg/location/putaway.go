@@ -0,0 +1,88 @@
+package location
+
+import (
+	"sort"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+func Free(loc *model.Location, occupied int64) (int64, bool) {
+	if !loc.Capacity.Valid {
+		return 0, false
+	}
+	if occupied >= loc.Capacity.Int64 {
+		return 0, true
+	}
+
+	return loc.Capacity.Int64 - occupied, true
+}
+
+func Suggest(locations []*model.Location, basePapers []*model.BasePaper, bp *model.BasePaper) []*SuggestionResponse {
+	occupied := make(map[string]int64)
+	sameSpec := make(map[string]bool)
+	sameGroup := make(map[string]bool)
+
+	for _, stored := range basePapers {
+		occupied[stored.Location] += stored.Quantity
+
+		if stored.Gsm == bp.Gsm && stored.Width == bp.Width && stored.MaterialNumber == bp.MaterialNumber {
+			sameGroup[stored.Location] = true
+			if stored.Io == bp.Io {
+				sameSpec[stored.Location] = true
+			}
+		}
+	}
+
+	suggestions := make([]*SuggestionResponse, 0)
+
+	for _, loc := range locations {
+		if !loc.IsActive {
+			continue
+		}
+
+		suggestion := &SuggestionResponse{
+			LocationID: loc.ID,
+			Code:       loc.Code,
+			Capacity:   nullableCapacity(loc.Capacity),
+			Occupied:   occupied[loc.Code],
+			SameSpec:   sameSpec[loc.Code],
+			SameGroup:  sameGroup[loc.Code],
+			Fits:       true,
+		}
+
+		free, limited := Free(loc, occupied[loc.Code])
+		if limited {
+			if free == 0 {
+				continue
+			}
+
+			suggestion.Free = &free
+			suggestion.Fits = free >= bp.Quantity
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.SameSpec != b.SameSpec {
+			return a.SameSpec
+		}
+		if a.SameGroup != b.SameGroup {
+			return a.SameGroup
+		}
+		if a.Fits != b.Fits {
+			return a.Fits
+		}
+		if (a.Free == nil) != (b.Free == nil) {
+			return a.Free == nil
+		}
+		if a.Free != nil && *a.Free != *b.Free {
+			return *a.Free > *b.Free
+		}
+
+		return a.Code < b.Code
+	})
+
+	return suggestions
+}
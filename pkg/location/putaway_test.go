@@ -0,0 +1,56 @@
+package location
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFree(t *testing.T) {
+	free, limited := Free(&model.Location{}, 10)
+	assert.False(t, limited)
+	assert.Equal(t, int64(0), free)
+
+	free, limited = Free(&model.Location{Capacity: sql.NullInt64{Int64: 8, Valid: true}}, 5)
+	assert.True(t, limited)
+	assert.Equal(t, int64(3), free)
+
+	free, _ = Free(&model.Location{Capacity: sql.NullInt64{Int64: 8, Valid: true}}, 9)
+	assert.Equal(t, int64(0), free)
+}
+
+func TestSuggest(t *testing.T) {
+	capacity := func(n int64) sql.NullInt64 { return sql.NullInt64{Int64: n, Valid: true} }
+
+	locations := []*model.Location{
+		{ID: 1, Code: "A-01-01", Capacity: capacity(10), IsActive: true},
+		{ID: 2, Code: "A-01-02", Capacity: capacity(10), IsActive: true},
+		{ID: 3, Code: "A-01-03", Capacity: capacity(4), IsActive: true},
+		{ID: 4, Code: "A-01-04", IsActive: true},
+		{ID: 5, Code: "A-01-05", Capacity: capacity(10), IsActive: false},
+		{ID: 6, Code: "A-01-06", Capacity: capacity(5), IsActive: true},
+	}
+	basePapers := []*model.BasePaper{
+		{Location: "A-01-01", Gsm: 80, Width: 100, Io: 2, MaterialNumber: 7, Quantity: 6},
+		{Location: "A-01-02", Gsm: 80, Width: 100, Io: 3, MaterialNumber: 7, Quantity: 2},
+		{Location: "A-01-06", Gsm: 90, Width: 100, Io: 2, MaterialNumber: 7, Quantity: 5},
+	}
+	bp := &model.BasePaper{Gsm: 80, Width: 100, Io: 2, MaterialNumber: 7, Quantity: 5}
+
+	suggestions := Suggest(locations, basePapers, bp)
+
+	codes := make([]string, 0)
+	for _, suggestion := range suggestions {
+		codes = append(codes, suggestion.Code)
+	}
+
+	assert.Equal(t, []string{"A-01-01", "A-01-02", "A-01-04", "A-01-03"}, codes)
+	assert.True(t, suggestions[0].SameSpec)
+	assert.False(t, suggestions[0].Fits)
+	assert.Equal(t, int64(4), *suggestions[0].Free)
+	assert.True(t, suggestions[1].SameGroup)
+	assert.Nil(t, suggestions[2].Free)
+	assert.False(t, suggestions[3].Fits)
+}
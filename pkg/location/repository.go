@@ -0,0 +1,15 @@
+package location
+
+import (
+	"context"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, loc *model.Location) error
+	FindByID(ctx context.Context, locationID int64) (*model.Location, error)
+	FindByCode(ctx context.Context, storageID int64, code string) (*model.Location, error)
+	FindByStorageID(ctx context.Context, storageID int64) ([]*model.Location, error)
+	Update(ctx context.Context, loc *model.Location) error
+}
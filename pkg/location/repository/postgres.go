@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/location"
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) location.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Create(ctx context.Context, loc *model.Location) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			INSERT INTO
+				Location
+				(storage_id, zone, rack, bin, code, capacity, is_active, created_at, updated_at)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING
+				id
+	`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		loc.Storage.ID,
+		loc.Zone,
+		loc.Rack,
+		loc.Bin,
+		loc.Code,
+		loc.Capacity,
+		loc.IsActive,
+		loc.CreatedAt,
+		loc.UpdatedAt,
+	).Scan(&loc.ID)
+
+	return err
+}
+
+func (r *repository) FindByID(ctx context.Context, locationID int64) (*model.Location, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, storage_id, zone, rack, bin, code, capacity, is_active, created_at, updated_at
+			FROM
+				Location
+			WHERE
+				id = $1
+	`
+
+	loc, err := scanLocation(tx.QueryRowContext(ctx, query, locationID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, app.NewError(err, app.ENotFound)
+		}
+		return nil, err
+	}
+
+	return loc, nil
+}
+
+func (r *repository) FindByCode(ctx context.Context, storageID int64, code string) (*model.Location, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, storage_id, zone, rack, bin, code, capacity, is_active, created_at, updated_at
+			FROM
+				Location
+			WHERE
+				storage_id = $1 AND code = $2
+			FOR UPDATE
+	`
+
+	loc, err := scanLocation(tx.QueryRowContext(ctx, query, storageID, code))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, app.NewError(err, app.ENotFound)
+		}
+		return nil, err
+	}
+
+	return loc, nil
+}
+
+func (r *repository) FindByStorageID(ctx context.Context, storageID int64) ([]*model.Location, error) {
+	query := `
+			SELECT
+				id, storage_id, zone, rack, bin, code, capacity, is_active, created_at, updated_at
+			FROM
+				Location
+			WHERE
+				storage_id = $1
+			ORDER BY
+				zone ASC, rack ASC, bin ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	locations := make([]*model.Location, 0)
+
+	for rows.Next() {
+		loc, err := scanLocation(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		locations = append(locations, loc)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+func (r *repository) Update(ctx context.Context, loc *model.Location) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			UPDATE
+				Location
+			SET
+				capacity = $1,
+				is_active = $2,
+				updated_at = $3
+			WHERE
+				id = $4
+	`
+
+	res, err := tx.ExecContext(ctx, query, loc.Capacity, loc.IsActive, loc.UpdatedAt, loc.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return app.NewError(nil, app.ENotFound)
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanLocation(row scanner) (*model.Location, error) {
+	var loc model.Location
+
+	err := row.Scan(
+		&loc.ID,
+		&loc.Storage.ID,
+		&loc.Zone,
+		&loc.Rack,
+		&loc.Bin,
+		&loc.Code,
+		&loc.Capacity,
+		&loc.IsActive,
+		&loc.CreatedAt,
+		&loc.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &loc, nil
+}
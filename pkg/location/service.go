@@ -0,0 +1,11 @@
+package location
+
+import "context"
+
+type Service interface {
+	Create(ctx context.Context, req *CreateLocationRequest) (*LocationResponse, error)
+	GetByStorageID(ctx context.Context, storageID int64) ([]*ZoneResponse, error)
+	SetActive(ctx context.Context, req *SetActiveRequest) (*LocationResponse, error)
+	SetCapacity(ctx context.Context, req *SetCapacityRequest) (*LocationResponse, error)
+	Suggest(ctx context.Context, basePaperID int64) ([]*SuggestionResponse, error)
+}
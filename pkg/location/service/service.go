@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/basepaper"
+	"github.com/bagus2x/tjiwi/pkg/location"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	stormemb "github.com/bagus2x/tjiwi/pkg/storagemember"
+	"github.com/bagus2x/tjiwi/utils"
+)
+
+type service struct {
+	locationRepo  location.Repository
+	basePaperRepo basepaper.Repository
+	storMembRepo  stormemb.Repository
+}
+
+func New(locationRepo location.Repository, basePaperRepo basepaper.Repository, storMembRepo stormemb.Repository) location.Service {
+	return &service{
+		locationRepo:  locationRepo,
+		basePaperRepo: basePaperRepo,
+		storMembRepo:  storMembRepo,
+	}
+}
+
+func (s *service) Create(ctx context.Context, req *location.CreateLocationRequest) (*location.LocationResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, req.StorageID, memberID, true); err != nil {
+		return nil, err
+	}
+
+	loc := model.Location{
+		Storage:   model.Storage{ID: req.StorageID},
+		Zone:      strings.ToUpper(req.Zone),
+		Rack:      strings.ToUpper(req.Rack),
+		Bin:       strings.ToUpper(req.Bin),
+		Code:      location.Code(req.Zone, req.Rack, req.Bin),
+		IsActive:  true,
+		CreatedAt: time.Now().Unix(),
+		UpdatedAt: time.Now().Unix(),
+	}
+
+	if req.Capacity != nil {
+		loc.Capacity = db.NewNullInt(*req.Capacity, true)
+	}
+
+	_, err = s.locationRepo.FindByCode(ctx, loc.Storage.ID, loc.Code)
+	if err == nil {
+		return nil, app.NewError(nil, app.Econflict, "Location already exist")
+	} else if app.ErrorCode(err) != app.ENotFound {
+		return nil, err
+	}
+
+	err = s.locationRepo.Create(ctx, &loc)
+	if err != nil {
+		return nil, err
+	}
+
+	return location.ToLocationResponse(&loc), nil
+}
+
+func (s *service) GetByStorageID(ctx context.Context, storageID int64) ([]*location.ZoneResponse, error) {
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, storageID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	locations, err := s.locationRepo.FindByStorageID(ctx, storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0)
+	for _, loc := range locations {
+		codes = append(codes, loc.Code)
+	}
+
+	basePapers := make([]*model.BasePaper, 0)
+	if len(codes) != 0 {
+		basePapers, err = s.basePaperRepo.FindByLocations(ctx, storageID, codes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return location.Tree(locations, basePapers), nil
+}
+
+func (s *service) SetActive(ctx context.Context, req *location.SetActiveRequest) (*location.LocationResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	loc, err := s.findLocation(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc.IsActive = req.IsActive
+	loc.UpdatedAt = time.Now().Unix()
+
+	err = s.locationRepo.Update(ctx, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	return location.ToLocationResponse(loc), nil
+}
+
+func (s *service) SetCapacity(ctx context.Context, req *location.SetCapacityRequest) (*location.LocationResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	loc, err := s.findLocation(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc.Capacity = db.NewNullInt(0, false)
+	if req.Capacity != nil {
+		loc.Capacity = db.NewNullInt(*req.Capacity, true)
+	}
+	loc.UpdatedAt = time.Now().Unix()
+
+	err = s.locationRepo.Update(ctx, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	return location.ToLocationResponse(loc), nil
+}
+
+func (s *service) Suggest(ctx context.Context, basePaperID int64) ([]*location.SuggestionResponse, error) {
+	bp, err := s.basePaperRepo.FindByID(ctx, basePaperID)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil, app.NewError(nil, app.ENotFound, "Base paper not found")
+	} else if err != nil {
+		return nil, err
+	}
+	if bp.Location != "" || bp.Quantity == 0 {
+		return nil, app.NewError(nil, app.EBadRequest, "Base paper is not in the buffer area")
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, bp.Storage.ID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	locations, err := s.locationRepo.FindByStorageID(ctx, bp.Storage.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0)
+	for _, loc := range locations {
+		codes = append(codes, loc.Code)
+	}
+
+	basePapers := make([]*model.BasePaper, 0)
+	if len(codes) != 0 {
+		basePapers, err = s.basePaperRepo.FindByLocations(ctx, bp.Storage.ID, codes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return location.Suggest(locations, basePapers, bp), nil
+}
+
+func (s *service) findLocation(ctx context.Context, locationID int64) (*model.Location, error) {
+	loc, err := s.locationRepo.FindByID(ctx, locationID)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil, app.NewError(nil, app.ENotFound, "Location not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, loc.Storage.ID, memberID, true); err != nil {
+		return nil, err
+	}
+
+	return loc, nil
+}
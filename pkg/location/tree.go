@@ -0,0 +1,93 @@
+package location
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+func Code(zone, rack, bin string) string {
+	return strings.ToUpper(strings.Join([]string{zone, rack, bin}, "-"))
+}
+
+func ToLocationResponse(loc *model.Location) *LocationResponse {
+	return &LocationResponse{
+		ID:        loc.ID,
+		StorageID: loc.Storage.ID,
+		Zone:      loc.Zone,
+		Rack:      loc.Rack,
+		Bin:       loc.Bin,
+		Code:      loc.Code,
+		Capacity:  nullableCapacity(loc.Capacity),
+		IsActive:  loc.IsActive,
+		Stock:     make([]*StockResponse, 0),
+		CreatedAt: loc.CreatedAt,
+		UpdatedAt: loc.UpdatedAt,
+	}
+}
+
+func nullableCapacity(capacity sql.NullInt64) *int64 {
+	if !capacity.Valid {
+		return nil
+	}
+
+	return &capacity.Int64
+}
+
+func Tree(locations []*model.Location, basePapers []*model.BasePaper) []*ZoneResponse {
+	byCode := make(map[string]*LocationResponse)
+	zones := make([]*ZoneResponse, 0)
+	zoneIndex := make(map[string]*ZoneResponse)
+	rackIndex := make(map[string]*RackResponse)
+
+	for _, loc := range locations {
+		bin := ToLocationResponse(loc)
+		byCode[loc.Code] = bin
+
+		zone, ok := zoneIndex[loc.Zone]
+		if !ok {
+			zone = &ZoneResponse{Zone: loc.Zone, Racks: make([]*RackResponse, 0)}
+			zoneIndex[loc.Zone] = zone
+			zones = append(zones, zone)
+		}
+
+		rackKey := loc.Zone + "/" + loc.Rack
+		rack, ok := rackIndex[rackKey]
+		if !ok {
+			rack = &RackResponse{Rack: loc.Rack, Bins: make([]*LocationResponse, 0)}
+			rackIndex[rackKey] = rack
+			zone.Racks = append(zone.Racks, rack)
+		}
+
+		rack.Bins = append(rack.Bins, bin)
+	}
+
+	for _, bp := range basePapers {
+		bin, ok := byCode[bp.Location]
+		if !ok {
+			continue
+		}
+
+		bin.Quantity += bp.Quantity
+		bin.Stock = append(bin.Stock, &StockResponse{
+			BasePaperID:    bp.ID,
+			Gsm:            bp.Gsm,
+			Width:          bp.Width,
+			Io:             bp.Io,
+			MaterialNumber: bp.MaterialNumber,
+			Quantity:       bp.Quantity,
+		})
+	}
+
+	for _, zone := range zones {
+		for _, rack := range zone.Racks {
+			for _, bin := range rack.Bins {
+				rack.Quantity += bin.Quantity
+			}
+			zone.Quantity += rack.Quantity
+		}
+	}
+
+	return zones
+}
@@ -0,0 +1,49 @@
+package location
+
+import (
+	"testing"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCode(t *testing.T) {
+	assert.Equal(t, "A-01-03", Code("a", "01", "03"))
+}
+
+func TestCreateLocationRequestValidate(t *testing.T) {
+	req := CreateLocationRequest{StorageID: 1, Zone: "A", Rack: "01", Bin: "03"}
+	assert.NoError(t, req.Validate())
+
+	req.Zone = "ZONEA"
+	req.Rack = "RACK1"
+	assert.Error(t, req.Validate())
+
+	req = CreateLocationRequest{StorageID: 1, Zone: "A-", Rack: "01", Bin: "03"}
+	assert.Error(t, req.Validate())
+}
+
+func TestTree(t *testing.T) {
+	locations := []*model.Location{
+		{ID: 1, Zone: "A", Rack: "01", Bin: "01", Code: "A-01-01"},
+		{ID: 2, Zone: "A", Rack: "01", Bin: "02", Code: "A-01-02"},
+		{ID: 3, Zone: "A", Rack: "02", Bin: "01", Code: "A-02-01"},
+		{ID: 4, Zone: "B", Rack: "01", Bin: "01", Code: "B-01-01"},
+	}
+	basePapers := []*model.BasePaper{
+		{ID: 10, Location: "A-01-01", Quantity: 3},
+		{ID: 11, Location: "A-01-01", Quantity: 2},
+		{ID: 12, Location: "A-02-01", Quantity: 4},
+		{ID: 13, Location: "", Quantity: 9},
+	}
+
+	zones := Tree(locations, basePapers)
+
+	assert.Len(t, zones, 2)
+	assert.Equal(t, int64(9), zones[0].Quantity)
+	assert.Len(t, zones[0].Racks, 2)
+	assert.Equal(t, int64(5), zones[0].Racks[0].Quantity)
+	assert.Len(t, zones[0].Racks[0].Bins[0].Stock, 2)
+	assert.Equal(t, int64(0), zones[0].Racks[0].Bins[1].Quantity)
+	assert.Equal(t, int64(0), zones[1].Quantity)
+}
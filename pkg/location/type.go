@@ -0,0 +1,99 @@
+package location
+
+import (
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/go-playground/validator/v10"
+)
+
+type CreateLocationRequest struct {
+	StorageID int64  `json:"storageID" validate:"required"`
+	Zone      string `json:"zone" validate:"required,alphanum,lte=10"`
+	Rack      string `json:"rack" validate:"required,alphanum,lte=10"`
+	Bin       string `json:"bin" validate:"required,alphanum,lte=10"`
+	Capacity  *int64 `json:"capacity" validate:"omitempty,gte=0"`
+}
+
+func (r *CreateLocationRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return app.ValidateAndTranslate(validate, err)
+	}
+
+	if len(Code(r.Zone, r.Rack, r.Bin)) > 10 {
+		return app.NewError(nil, app.EBadRequest, "Location code must be at most 10 characters")
+	}
+
+	return nil
+}
+
+type SetActiveRequest struct {
+	ID       int64 `json:"id" validate:"required"`
+	IsActive bool  `json:"isActive"`
+}
+
+func (r *SetActiveRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type SetCapacityRequest struct {
+	ID       int64  `json:"id" validate:"required"`
+	Capacity *int64 `json:"capacity" validate:"omitempty,gte=0"`
+}
+
+func (r *SetCapacityRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type StockResponse struct {
+	BasePaperID    int64 `json:"basePaperID"`
+	Gsm            int64 `json:"gsm"`
+	Width          int64 `json:"width"`
+	Io             int64 `json:"io"`
+	MaterialNumber int64 `json:"materialNumber"`
+	Quantity       int64 `json:"quantity"`
+}
+
+type LocationResponse struct {
+	ID        int64            `json:"id"`
+	StorageID int64            `json:"storageID"`
+	Zone      string           `json:"zone"`
+	Rack      string           `json:"rack"`
+	Bin       string           `json:"bin"`
+	Code      string           `json:"code"`
+	Capacity  *int64           `json:"capacity"`
+	IsActive  bool             `json:"isActive"`
+	Quantity  int64            `json:"quantity"`
+	Stock     []*StockResponse `json:"stock"`
+	CreatedAt int64            `json:"createdAt"`
+	UpdatedAt int64            `json:"updatedAt"`
+}
+
+type RackResponse struct {
+	Rack     string              `json:"rack"`
+	Quantity int64               `json:"quantity"`
+	Bins     []*LocationResponse `json:"bins"`
+}
+
+type ZoneResponse struct {
+	Zone     string          `json:"zone"`
+	Quantity int64           `json:"quantity"`
+	Racks    []*RackResponse `json:"racks"`
+}
+
+type SuggestionResponse struct {
+	LocationID int64  `json:"locationID"`
+	Code       string `json:"code"`
+	Capacity   *int64 `json:"capacity"`
+	Occupied   int64  `json:"occupied"`
+	Free       *int64 `json:"free"`
+	SameSpec   bool   `json:"sameSpec"`
+	SameGroup  bool   `json:"sameGroup"`
+	Fits       bool   `json:"fits"`
+}
@@ -0,0 +1,16 @@
+package material
+
+import (
+	"context"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, m *model.Material) error
+	FindByID(ctx context.Context, materialID int64) (*model.Material, error)
+	FindByNumber(ctx context.Context, storageID, number int64) (*model.Material, error)
+	FindByNumbers(ctx context.Context, storageID int64, numbers []int64) ([]*model.Material, error)
+	FindByStorageID(ctx context.Context, storageID int64) ([]*model.Material, error)
+	Update(ctx context.Context, m *model.Material) error
+}
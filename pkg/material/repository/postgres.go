@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/material"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/lib/pq"
+)
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) material.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Create(ctx context.Context, m *model.Material) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			INSERT INTO
+				Material
+				(storage_id, number, description, grade, supplier, default_gsm, default_io, is_active, created_at,
+				updated_at)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			RETURNING
+				id
+	`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		m.Storage.ID,
+		m.Number,
+		m.Description,
+		m.Grade,
+		m.Supplier,
+		m.DefaultGsm,
+		m.DefaultIo,
+		m.IsActive,
+		m.CreatedAt,
+		m.UpdatedAt,
+	).Scan(&m.ID)
+
+	return err
+}
+
+func (r *repository) FindByID(ctx context.Context, materialID int64) (*model.Material, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, storage_id, number, description, grade, supplier, default_gsm, default_io, is_active, created_at,
+				updated_at
+			FROM
+				Material
+			WHERE
+				id = $1
+	`
+
+	m, err := scanMaterial(tx.QueryRowContext(ctx, query, materialID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, app.NewError(err, app.ENotFound)
+		}
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (r *repository) FindByNumber(ctx context.Context, storageID, number int64) (*model.Material, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, storage_id, number, description, grade, supplier, default_gsm, default_io, is_active, created_at,
+				updated_at
+			FROM
+				Material
+			WHERE
+				storage_id = $1 AND number = $2
+	`
+
+	m, err := scanMaterial(tx.QueryRowContext(ctx, query, storageID, number))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, app.NewError(err, app.ENotFound)
+		}
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (r *repository) FindByNumbers(ctx context.Context, storageID int64, numbers []int64) ([]*model.Material, error) {
+	query := `
+			SELECT
+				id, storage_id, number, description, grade, supplier, default_gsm, default_io, is_active, created_at,
+				updated_at
+			FROM
+				Material
+			WHERE
+				storage_id = $1 AND number = ANY($2)
+	`
+
+	return r.findMaterials(ctx, query, storageID, pq.Array(numbers))
+}
+
+func (r *repository) FindByStorageID(ctx context.Context, storageID int64) ([]*model.Material, error) {
+	query := `
+			SELECT
+				id, storage_id, number, description, grade, supplier, default_gsm, default_io, is_active, created_at,
+				updated_at
+			FROM
+				Material
+			WHERE
+				storage_id = $1
+			ORDER BY
+				number ASC
+	`
+
+	return r.findMaterials(ctx, query, storageID)
+}
+
+func (r *repository) Update(ctx context.Context, m *model.Material) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			UPDATE
+				Material
+			SET
+				description = $1,
+				grade = $2,
+				supplier = $3,
+				default_gsm = $4,
+				default_io = $5,
+				is_active = $6,
+				updated_at = $7
+			WHERE
+				id = $8
+	`
+
+	res, err := tx.ExecContext(
+		ctx,
+		query,
+		m.Description,
+		m.Grade,
+		m.Supplier,
+		m.DefaultGsm,
+		m.DefaultIo,
+		m.IsActive,
+		m.UpdatedAt,
+		m.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return app.NewError(nil, app.ENotFound)
+	}
+
+	return nil
+}
+
+func (r *repository) findMaterials(ctx context.Context, query string, args ...interface{}) ([]*model.Material, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	materials := make([]*model.Material, 0)
+
+	for rows.Next() {
+		m, err := scanMaterial(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		materials = append(materials, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return materials, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanMaterial(row scanner) (*model.Material, error) {
+	var m model.Material
+
+	err := row.Scan(
+		&m.ID,
+		&m.Storage.ID,
+		&m.Number,
+		&m.Description,
+		&m.Grade,
+		&m.Supplier,
+		&m.DefaultGsm,
+		&m.DefaultIo,
+		&m.IsActive,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
@@ -0,0 +1,10 @@
+package material
+
+import "context"
+
+type Service interface {
+	Create(ctx context.Context, req *CreateMaterialRequest) (*MaterialResponse, error)
+	GetByID(ctx context.Context, materialID int64) (*MaterialResponse, error)
+	GetByStorageID(ctx context.Context, storageID int64) ([]*MaterialResponse, error)
+	Update(ctx context.Context, req *UpdateMaterialRequest) (*MaterialResponse, error)
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/material"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	stormemb "github.com/bagus2x/tjiwi/pkg/storagemember"
+	"github.com/bagus2x/tjiwi/utils"
+)
+
+type service struct {
+	materialRepo material.Repository
+	storMembRepo stormemb.Repository
+}
+
+func New(materialRepo material.Repository, storMembRepo stormemb.Repository) material.Service {
+	return &service{
+		materialRepo: materialRepo,
+		storMembRepo: storMembRepo,
+	}
+}
+
+func (s *service) Create(ctx context.Context, req *material.CreateMaterialRequest) (*material.MaterialResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, req.StorageID, memberID, true); err != nil {
+		return nil, err
+	}
+
+	_, err = s.materialRepo.FindByNumber(ctx, req.StorageID, req.Number)
+	if err == nil {
+		return nil, app.NewError(nil, app.Econflict, "Material number already exist")
+	} else if app.ErrorCode(err) != app.ENotFound {
+		return nil, err
+	}
+
+	m := model.Material{
+		Storage:     model.Storage{ID: req.StorageID},
+		Number:      req.Number,
+		Description: req.Description,
+		Grade:       req.Grade,
+		Supplier:    req.Supplier,
+		DefaultGsm:  nullableInt(req.DefaultGsm),
+		DefaultIo:   nullableInt(req.DefaultIo),
+		IsActive:    true,
+		CreatedAt:   time.Now().Unix(),
+		UpdatedAt:   time.Now().Unix(),
+	}
+
+	err = s.materialRepo.Create(ctx, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	return toMaterialResponse(&m), nil
+}
+
+func (s *service) GetByID(ctx context.Context, materialID int64) (*material.MaterialResponse, error) {
+	m, err := s.materialRepo.FindByID(ctx, materialID)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil, app.NewError(nil, app.ENotFound, "Material not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, m.Storage.ID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	return toMaterialResponse(m), nil
+}
+
+func (s *service) GetByStorageID(ctx context.Context, storageID int64) ([]*material.MaterialResponse, error) {
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, storageID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	materials, err := s.materialRepo.FindByStorageID(ctx, storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*material.MaterialResponse, 0)
+	for _, m := range materials {
+		res = append(res, toMaterialResponse(m))
+	}
+
+	return res, nil
+}
+
+func (s *service) Update(ctx context.Context, req *material.UpdateMaterialRequest) (*material.MaterialResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	m, err := s.materialRepo.FindByID(ctx, req.ID)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil, app.NewError(nil, app.ENotFound, "Material not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, m.Storage.ID, memberID, true); err != nil {
+		return nil, err
+	}
+
+	m.Description = req.Description
+	m.Grade = req.Grade
+	m.Supplier = req.Supplier
+	m.DefaultGsm = nullableInt(req.DefaultGsm)
+	m.DefaultIo = nullableInt(req.DefaultIo)
+	m.IsActive = req.IsActive
+	m.UpdatedAt = time.Now().Unix()
+
+	err = s.materialRepo.Update(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return toMaterialResponse(m), nil
+}
+
+func nullableInt(value *int64) sql.NullInt64 {
+	if value == nil {
+		return db.NewNullInt(0, false)
+	}
+
+	return db.NewNullInt(*value, true)
+}
+
+func intPointer(value sql.NullInt64) *int64 {
+	if !value.Valid {
+		return nil
+	}
+
+	return &value.Int64
+}
+
+func toMaterialResponse(m *model.Material) *material.MaterialResponse {
+	return &material.MaterialResponse{
+		ID:          m.ID,
+		StorageID:   m.Storage.ID,
+		Number:      m.Number,
+		Description: m.Description,
+		Grade:       m.Grade,
+		Supplier:    m.Supplier,
+		DefaultGsm:  intPointer(m.DefaultGsm),
+		DefaultIo:   intPointer(m.DefaultIo),
+		IsActive:    m.IsActive,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
@@ -0,0 +1,54 @@
+package material
+
+import (
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/go-playground/validator/v10"
+)
+
+type CreateMaterialRequest struct {
+	StorageID   int64  `json:"storageID" validate:"required"`
+	Number      int64  `json:"number" validate:"required"`
+	Description string `json:"description" validate:"required,lte=255"`
+	Grade       string `json:"grade" validate:"lte=50"`
+	Supplier    string `json:"supplier" validate:"lte=255"`
+	DefaultGsm  *int64 `json:"defaultGsm" validate:"omitempty,gt=0"`
+	DefaultIo   *int64 `json:"defaultIo" validate:"omitempty,gt=0"`
+}
+
+func (r *CreateMaterialRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type UpdateMaterialRequest struct {
+	ID          int64  `json:"id" validate:"required"`
+	Description string `json:"description" validate:"required,lte=255"`
+	Grade       string `json:"grade" validate:"lte=50"`
+	Supplier    string `json:"supplier" validate:"lte=255"`
+	DefaultGsm  *int64 `json:"defaultGsm" validate:"omitempty,gt=0"`
+	DefaultIo   *int64 `json:"defaultIo" validate:"omitempty,gt=0"`
+	IsActive    bool   `json:"isActive"`
+}
+
+func (r *UpdateMaterialRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type MaterialResponse struct {
+	ID          int64  `json:"id"`
+	StorageID   int64  `json:"storageID"`
+	Number      int64  `json:"number"`
+	Description string `json:"description"`
+	Grade       string `json:"grade"`
+	Supplier    string `json:"supplier"`
+	DefaultGsm  *int64 `json:"defaultGsm"`
+	DefaultIo   *int64 `json:"defaultIo"`
+	IsActive    bool   `json:"isActive"`
+	CreatedAt   int64  `json:"createdAt"`
+	UpdatedAt   int64  `json:"updatedAt"`
+}
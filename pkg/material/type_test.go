@@ -0,0 +1,20 @@
+package material
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMaterialRequestValidate(t *testing.T) {
+	gsm := int64(80)
+	req := CreateMaterialRequest{StorageID: 1, Number: 4711, Description: "Kraft liner", DefaultGsm: &gsm}
+	assert.NoError(t, req.Validate())
+
+	req.Description = ""
+	assert.Error(t, req.Validate())
+
+	zero := int64(0)
+	req = CreateMaterialRequest{StorageID: 1, Number: 4711, Description: "Kraft liner", DefaultIo: &zero}
+	assert.Error(t, req.Validate())
+}
@@ -8,6 +8,7 @@ type BasePaper struct {
 	Io             int64
 	MaterialNumber int64
 	Quantity       int64
+	Weight         float64
 	Location       string
 	IsDeleted      bool
 	CreatedAt      int64
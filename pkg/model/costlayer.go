@@ -0,0 +1,19 @@
+package model
+
+import "database/sql"
+
+type CostLayer struct {
+	ID             int64
+	Storage        Storage
+	Gsm            int64
+	Width          int64
+	Io             int64
+	MaterialNumber int64
+	History        sql.NullInt64
+	Received       int64
+	Remaining      int64
+	UnitCost       sql.NullFloat64
+	AverageCost    sql.NullFloat64
+	CreatedAt      int64
+	UpdatedAt      int64
+}
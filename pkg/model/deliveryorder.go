@@ -0,0 +1,26 @@
+package model
+
+import "database/sql"
+
+type DeliveryOrder struct {
+	ID        int64
+	Storage   Storage
+	Number    string
+	Customer  string
+	Status    string
+	Member    User
+	Lines     []*DeliveryOrderLine
+	ShippedAt sql.NullInt64
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+type DeliveryOrderLine struct {
+	ID             int64
+	DeliveryOrder  DeliveryOrder
+	Gsm            int64
+	Width          int64
+	Io             int64
+	MaterialNumber int64
+	Quantity       int64
+}
@@ -1,11 +1,21 @@
 package model
 
+import "database/sql"
+
 type History struct {
-	ID        int64
-	Storage   Storage
-	BasePaper BasePaper
-	Member    User
-	Status    string
-	Affected  int64
-	CreatedAt int64
+	ID                int64
+	Storage           Storage
+	BasePaper         BasePaper
+	Member            User
+	Status            string
+	Affected          int64
+	AffectedWeight    float64
+	FromLocation      sql.NullString
+	ToLocation        sql.NullString
+	Reason            sql.NullString
+	Note              sql.NullString
+	Reference         sql.NullInt64
+	DeliveryOrder     sql.NullInt64
+	PurchaseOrderLine sql.NullInt64
+	CreatedAt         int64
 }
@@ -0,0 +1,16 @@
+package model
+
+import "database/sql"
+
+type Location struct {
+	ID        int64
+	Storage   Storage
+	Zone      string
+	Rack      string
+	Bin       string
+	Code      string
+	Capacity  sql.NullInt64
+	IsActive  bool
+	CreatedAt int64
+	UpdatedAt int64
+}
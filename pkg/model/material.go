@@ -0,0 +1,17 @@
+package model
+
+import "database/sql"
+
+type Material struct {
+	ID          int64
+	Storage     Storage
+	Number      int64
+	Description string
+	Grade       string
+	Supplier    string
+	DefaultGsm  sql.NullInt64
+	DefaultIo   sql.NullInt64
+	IsActive    bool
+	CreatedAt   int64
+	UpdatedAt   int64
+}
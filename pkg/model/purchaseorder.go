@@ -0,0 +1,25 @@
+package model
+
+type PurchaseOrder struct {
+	ID         int64
+	Storage    Storage
+	Number     string
+	Supplier   string
+	ExpectedAt int64
+	Status     string
+	Member     User
+	Lines      []*PurchaseOrderLine
+	CreatedAt  int64
+	UpdatedAt  int64
+}
+
+type PurchaseOrderLine struct {
+	ID             int64
+	PurchaseOrder  PurchaseOrder
+	Gsm            int64
+	Width          int64
+	Io             int64
+	MaterialNumber int64
+	Expected       int64
+	Received       int64
+}
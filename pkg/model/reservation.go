@@ -0,0 +1,20 @@
+package model
+
+import "database/sql"
+
+type Reservation struct {
+	ID             int64
+	Storage        Storage
+	BasePaper      sql.NullInt64
+	Gsm            int64
+	Width          int64
+	Io             int64
+	MaterialNumber int64
+	OrderName      string
+	Quantity       int64
+	Status         string
+	Member         User
+	ExpiresAt      int64
+	CreatedAt      int64
+	UpdatedAt      int64
+}
@@ -0,0 +1,12 @@
+package model
+
+type Roll struct {
+	ID        int64
+	Storage   Storage
+	BasePaper BasePaper
+	Serial    string
+	Weight    float64
+	Status    string
+	CreatedAt int64
+	UpdatedAt int64
+}
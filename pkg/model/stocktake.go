@@ -0,0 +1,25 @@
+package model
+
+type StockTake struct {
+	ID        int64
+	Storage   Storage
+	Member    User
+	Locations []string
+	Status    string
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+type StockTakeCount struct {
+	ID             int64
+	StockTake      StockTake
+	Member         User
+	Location       string
+	Gsm            int64
+	Width          int64
+	Io             int64
+	MaterialNumber int64
+	Quantity       int64
+	CreatedAt      int64
+	UpdatedAt      int64
+}
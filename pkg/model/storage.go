@@ -3,11 +3,13 @@ package model
 import "database/sql"
 
 type Storage struct {
-	ID          int64
-	Supervisor  User
-	Name        string
-	Description sql.NullString
-	IsDeleted   bool
-	CreatedAt   int64
-	UpdatedAt   int64
+	ID              int64
+	Supervisor      User
+	Name            string
+	Description     sql.NullString
+	EnforceFifo     bool
+	ValuationMethod string
+	IsDeleted       bool
+	CreatedAt       int64
+	UpdatedAt       int64
 }
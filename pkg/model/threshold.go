@@ -0,0 +1,29 @@
+package model
+
+import "database/sql"
+
+type Threshold struct {
+	ID             int64
+	Storage        Storage
+	Gsm            int64
+	Width          int64
+	MaterialNumber int64
+	Minimum        int64
+	Member         User
+	CreatedAt      int64
+	UpdatedAt      int64
+}
+
+type Alert struct {
+	ID             int64
+	Storage        Storage
+	Threshold      Threshold
+	Gsm            int64
+	Width          int64
+	MaterialNumber int64
+	Minimum        int64
+	Quantity       int64
+	Status         string
+	CreatedAt      int64
+	ResolvedAt     sql.NullInt64
+}
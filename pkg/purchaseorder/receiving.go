@@ -0,0 +1,55 @@
+package purchaseorder
+
+import "github.com/bagus2x/tjiwi/pkg/model"
+
+func Outstanding(line *model.PurchaseOrderLine) int64 {
+	if line.Received >= line.Expected {
+		return 0
+	}
+
+	return line.Expected - line.Received
+}
+
+func Over(line *model.PurchaseOrderLine) int64 {
+	if line.Received <= line.Expected {
+		return 0
+	}
+
+	return line.Received - line.Expected
+}
+
+func LineStatus(line *model.PurchaseOrderLine) string {
+	switch {
+	case line.Received == 0:
+		return "pending"
+	case line.Received < line.Expected:
+		return "partial"
+	case line.Received == line.Expected:
+		return "complete"
+	}
+
+	return "over"
+}
+
+func OrderStatus(lines []*model.PurchaseOrderLine) string {
+	received := false
+	complete := true
+
+	for _, line := range lines {
+		if line.Received > 0 {
+			received = true
+		}
+		if Outstanding(line) > 0 {
+			complete = false
+		}
+	}
+
+	if complete {
+		return "closed"
+	}
+	if received {
+		return "partial"
+	}
+
+	return "open"
+}
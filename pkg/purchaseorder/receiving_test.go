@@ -0,0 +1,47 @@
+package purchaseorder
+
+import (
+	"testing"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineStatus(t *testing.T) {
+	tests := []struct {
+		received    int64
+		status      string
+		outstanding int64
+		over        int64
+	}{
+		{0, "pending", 10, 0},
+		{4, "partial", 6, 0},
+		{10, "complete", 0, 0},
+		{13, "over", 0, 3},
+	}
+
+	for _, test := range tests {
+		line := &model.PurchaseOrderLine{Expected: 10, Received: test.received}
+
+		assert.Equal(t, test.status, LineStatus(line))
+		assert.Equal(t, test.outstanding, Outstanding(line))
+		assert.Equal(t, test.over, Over(line))
+	}
+}
+
+func TestOrderStatus(t *testing.T) {
+	assert.Equal(t, "open", OrderStatus([]*model.PurchaseOrderLine{
+		{Expected: 10},
+		{Expected: 5},
+	}))
+
+	assert.Equal(t, "partial", OrderStatus([]*model.PurchaseOrderLine{
+		{Expected: 10, Received: 12},
+		{Expected: 5},
+	}))
+
+	assert.Equal(t, "closed", OrderStatus([]*model.PurchaseOrderLine{
+		{Expected: 10, Received: 12},
+		{Expected: 5, Received: 5},
+	}))
+}
@@ -0,0 +1,18 @@
+package purchaseorder
+
+import (
+	"context"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, order *model.PurchaseOrder) error
+	FindByID(ctx context.Context, purchaseOrderID int64) (*model.PurchaseOrder, error)
+	FindByNumber(ctx context.Context, storageID int64, number string) (*model.PurchaseOrder, error)
+	FindByStorageID(ctx context.Context, storageID int64) ([]*model.PurchaseOrder, error)
+	FindLineByID(ctx context.Context, lineID int64) (*model.PurchaseOrderLine, error)
+	Update(ctx context.Context, order *model.PurchaseOrder) error
+	UpdateLine(ctx context.Context, line *model.PurchaseOrderLine) error
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+}
@@ -0,0 +1,368 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/purchaseorder"
+	"github.com/sirupsen/logrus"
+)
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) purchaseorder.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Create(ctx context.Context, order *model.PurchaseOrder) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			INSERT INTO
+				Purchase_Order
+				(storage_id, number, supplier, expected_at, status, member_id, created_at, updated_at)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING
+				id
+	`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		order.Storage.ID,
+		order.Number,
+		order.Supplier,
+		order.ExpectedAt,
+		order.Status,
+		order.Member.ID,
+		order.CreatedAt,
+		order.UpdatedAt,
+	).Scan(&order.ID)
+	if err != nil {
+		return err
+	}
+
+	query = `
+			INSERT INTO
+				Purchase_Order_Line
+				(purchase_order_id, gsm, width, io, material_number, expected, received)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7)
+			RETURNING
+				id
+	`
+
+	for _, line := range order.Lines {
+		line.PurchaseOrder.ID = order.ID
+
+		err := tx.QueryRowContext(
+			ctx,
+			query,
+			line.PurchaseOrder.ID,
+			line.Gsm,
+			line.Width,
+			line.Io,
+			line.MaterialNumber,
+			line.Expected,
+			line.Received,
+		).Scan(&line.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *repository) FindByID(ctx context.Context, purchaseOrderID int64) (*model.PurchaseOrder, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, storage_id, number, supplier, expected_at, status, member_id, created_at, updated_at
+			FROM
+				Purchase_Order
+			WHERE
+				id = $1
+			FOR UPDATE
+	`
+
+	order, err := scanPurchaseOrder(tx.QueryRowContext(ctx, query, purchaseOrderID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, app.NewError(err, app.ENotFound)
+		}
+		return nil, err
+	}
+
+	order.Lines, err = r.findLines(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (r *repository) FindByNumber(ctx context.Context, storageID int64, number string) (*model.PurchaseOrder, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, storage_id, number, supplier, expected_at, status, member_id, created_at, updated_at
+			FROM
+				Purchase_Order
+			WHERE
+				storage_id = $1 AND number = $2
+	`
+
+	order, err := scanPurchaseOrder(tx.QueryRowContext(ctx, query, storageID, number))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, app.NewError(err, app.ENotFound)
+		}
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (r *repository) FindByStorageID(ctx context.Context, storageID int64) ([]*model.PurchaseOrder, error) {
+	query := `
+			SELECT
+				id, storage_id, number, supplier, expected_at, status, member_id, created_at, updated_at
+			FROM
+				Purchase_Order
+			WHERE
+				storage_id = $1
+			ORDER BY
+				id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orders := make([]*model.PurchaseOrder, 0)
+
+	for rows.Next() {
+		order, err := scanPurchaseOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, order)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, order := range orders {
+		order.Lines, err = r.findLines(ctx, order.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return orders, nil
+}
+
+func (r *repository) FindLineByID(ctx context.Context, lineID int64) (*model.PurchaseOrderLine, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, purchase_order_id, gsm, width, io, material_number, expected, received
+			FROM
+				Purchase_Order_Line
+			WHERE
+				id = $1
+			FOR UPDATE
+	`
+
+	var line model.PurchaseOrderLine
+
+	err := tx.QueryRowContext(ctx, query, lineID).Scan(
+		&line.ID,
+		&line.PurchaseOrder.ID,
+		&line.Gsm,
+		&line.Width,
+		&line.Io,
+		&line.MaterialNumber,
+		&line.Expected,
+		&line.Received,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, app.NewError(err, app.ENotFound)
+		}
+		return nil, err
+	}
+
+	return &line, nil
+}
+
+func (r *repository) Update(ctx context.Context, order *model.PurchaseOrder) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			UPDATE
+				Purchase_Order
+			SET
+				status = $1,
+				updated_at = $2
+			WHERE
+				id = $3
+	`
+
+	res, err := tx.ExecContext(ctx, query, order.Status, order.UpdatedAt, order.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return app.NewError(nil, app.ENotFound)
+	}
+
+	return nil
+}
+
+func (r *repository) UpdateLine(ctx context.Context, line *model.PurchaseOrderLine) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			UPDATE
+				Purchase_Order_Line
+			SET
+				received = $1
+			WHERE
+				id = $2
+	`
+
+	res, err := tx.ExecContext(ctx, query, line.Received, line.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return app.NewError(nil, app.ENotFound)
+	}
+
+	return nil
+}
+
+func (r *repository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	c := context.WithValue(ctx, db.TransactionKey{}, tx)
+	err = fn(c)
+	if err != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			logrus.Error("Failed to rollback transaction", errTx)
+		}
+		return err
+	}
+
+	if errTX := tx.Commit(); errTX != nil {
+		logrus.Error("Failed to commmit transaction", errTX)
+	}
+
+	return nil
+}
+
+func (r *repository) findLines(ctx context.Context, purchaseOrderID int64) ([]*model.PurchaseOrderLine, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, purchase_order_id, gsm, width, io, material_number, expected, received
+			FROM
+				Purchase_Order_Line
+			WHERE
+				purchase_order_id = $1
+			ORDER BY
+				id ASC
+	`
+
+	rows, err := tx.QueryContext(ctx, query, purchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	lines := make([]*model.PurchaseOrderLine, 0)
+
+	for rows.Next() {
+		var line model.PurchaseOrderLine
+
+		err := rows.Scan(
+			&line.ID,
+			&line.PurchaseOrder.ID,
+			&line.Gsm,
+			&line.Width,
+			&line.Io,
+			&line.MaterialNumber,
+			&line.Expected,
+			&line.Received,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		lines = append(lines, &line)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPurchaseOrder(row scanner) (*model.PurchaseOrder, error) {
+	var order model.PurchaseOrder
+
+	err := row.Scan(
+		&order.ID,
+		&order.Storage.ID,
+		&order.Number,
+		&order.Supplier,
+		&order.ExpectedAt,
+		&order.Status,
+		&order.Member.ID,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
@@ -0,0 +1,12 @@
+package purchaseorder
+
+import "context"
+
+type Service interface {
+	Create(ctx context.Context, req *CreatePurchaseOrderRequest) (*PurchaseOrderResponse, error)
+	GetByID(ctx context.Context, purchaseOrderID int64) (*PurchaseOrderResponse, error)
+	GetByStorageID(ctx context.Context, storageID int64) ([]*PurchaseOrderResponse, error)
+	Receive(ctx context.Context, req *ReceivePurchaseOrderRequest) (*PurchaseOrderResponse, error)
+	Close(ctx context.Context, purchaseOrderID int64) (*PurchaseOrderResponse, error)
+	Cancel(ctx context.Context, purchaseOrderID int64) (*PurchaseOrderResponse, error)
+}
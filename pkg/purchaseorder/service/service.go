@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/pkg/basepaper"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/purchaseorder"
+	stormemb "github.com/bagus2x/tjiwi/pkg/storagemember"
+	"github.com/bagus2x/tjiwi/utils"
+)
+
+type service struct {
+	purchaseOrderRepo purchaseorder.Repository
+	storMembRepo      stormemb.Repository
+	basePaperService  basepaper.Service
+}
+
+func New(purchaseOrderRepo purchaseorder.Repository, storMembRepo stormemb.Repository, basePaperService basepaper.Service) purchaseorder.Service {
+	return &service{
+		purchaseOrderRepo: purchaseOrderRepo,
+		storMembRepo:      storMembRepo,
+		basePaperService:  basePaperService,
+	}
+}
+
+func (s *service) Create(ctx context.Context, req *purchaseorder.CreatePurchaseOrderRequest) (*purchaseorder.PurchaseOrderResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, req.StorageID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	lines := make([]*model.PurchaseOrderLine, 0)
+	for _, line := range req.Lines {
+		lines = append(lines, &model.PurchaseOrderLine{
+			Gsm:            line.Gsm,
+			Width:          line.Width,
+			Io:             line.Io,
+			MaterialNumber: line.MaterialNumber,
+			Expected:       line.Expected,
+		})
+	}
+
+	order := model.PurchaseOrder{
+		Storage:    model.Storage{ID: req.StorageID},
+		Number:     req.Number,
+		Supplier:   req.Supplier,
+		ExpectedAt: req.ExpectedAt,
+		Status:     "open",
+		Member:     model.User{ID: memberID},
+		Lines:      lines,
+		CreatedAt:  time.Now().Unix(),
+		UpdatedAt:  time.Now().Unix(),
+	}
+
+	err = s.purchaseOrderRepo.WithTransaction(ctx, func(c context.Context) error {
+		_, err := s.purchaseOrderRepo.FindByNumber(c, req.StorageID, req.Number)
+		if err == nil {
+			return app.NewError(nil, app.Econflict, "Purchase order number already exist")
+		} else if app.ErrorCode(err) != app.ENotFound {
+			return err
+		}
+
+		return s.purchaseOrderRepo.Create(c, &order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPurchaseOrderResponse(&order), nil
+}
+
+func (s *service) GetByID(ctx context.Context, purchaseOrderID int64) (*purchaseorder.PurchaseOrderResponse, error) {
+	order, err := s.findPurchaseOrder(ctx, purchaseOrderID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPurchaseOrderResponse(order), nil
+}
+
+func (s *service) GetByStorageID(ctx context.Context, storageID int64) ([]*purchaseorder.PurchaseOrderResponse, error) {
+	orders, err := s.purchaseOrderRepo.FindByStorageID(ctx, storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*purchaseorder.PurchaseOrderResponse, 0)
+	for _, order := range orders {
+		res = append(res, toPurchaseOrderResponse(order))
+	}
+
+	return res, nil
+}
+
+func (s *service) Receive(ctx context.Context, req *purchaseorder.ReceivePurchaseOrderRequest) (*purchaseorder.PurchaseOrderResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var res *purchaseorder.PurchaseOrderResponse
+
+	err := s.purchaseOrderRepo.WithTransaction(ctx, func(c context.Context) error {
+		order, err := s.findPurchaseOrder(c, req.ID, false)
+		if err != nil {
+			return err
+		}
+		if order.Status != "open" && order.Status != "partial" {
+			return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Purchase order is %s", order.Status))
+		}
+
+		lines := make(map[int64]*model.PurchaseOrderLine)
+		for _, line := range order.Lines {
+			lines[line.ID] = line
+		}
+
+		receipts := make([]*basepaper.AddBasePaperResponse, 0)
+
+		for _, received := range req.Lines {
+			line, ok := lines[received.LineID]
+			if !ok {
+				return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Line %d is not part of the purchase order", received.LineID))
+			}
+
+			receipt, err := s.basePaperService.StoreBasePaper(c, &basepaper.AddBasePaperRequest{
+				StorageID:           order.Storage.ID,
+				Gsm:                 line.Gsm,
+				Width:               line.Width,
+				Io:                  line.Io,
+				MaterialNumber:      line.MaterialNumber,
+				Quantity:            received.Quantity,
+				RollWeight:          received.RollWeight,
+				Length:              received.Length,
+				UnitCost:            received.UnitCost,
+				PurchaseOrderLineID: line.ID,
+			})
+			if err != nil {
+				return err
+			}
+
+			line.Received += received.Quantity
+
+			err = s.purchaseOrderRepo.UpdateLine(c, line)
+			if err != nil {
+				return err
+			}
+
+			receipts = append(receipts, receipt)
+		}
+
+		order.Status = purchaseorder.OrderStatus(order.Lines)
+		order.UpdatedAt = time.Now().Unix()
+
+		err = s.purchaseOrderRepo.Update(c, order)
+		if err != nil {
+			return err
+		}
+
+		res = toPurchaseOrderResponse(order)
+		res.Receipts = receipts
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *service) Close(ctx context.Context, purchaseOrderID int64) (*purchaseorder.PurchaseOrderResponse, error) {
+	var order *model.PurchaseOrder
+
+	err := s.purchaseOrderRepo.WithTransaction(ctx, func(c context.Context) error {
+		var err error
+		order, err = s.findPurchaseOrder(c, purchaseOrderID, true)
+		if err != nil {
+			return err
+		}
+		if order.Status != "open" && order.Status != "partial" {
+			return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Purchase order is %s", order.Status))
+		}
+
+		order.Status = "closed"
+		order.UpdatedAt = time.Now().Unix()
+
+		return s.purchaseOrderRepo.Update(c, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPurchaseOrderResponse(order), nil
+}
+
+func (s *service) Cancel(ctx context.Context, purchaseOrderID int64) (*purchaseorder.PurchaseOrderResponse, error) {
+	var order *model.PurchaseOrder
+
+	err := s.purchaseOrderRepo.WithTransaction(ctx, func(c context.Context) error {
+		var err error
+		order, err = s.findPurchaseOrder(c, purchaseOrderID, true)
+		if err != nil {
+			return err
+		}
+		if order.Status != "open" {
+			return app.NewError(nil, app.EBadRequest, "Only purchase orders without receipts can be cancelled")
+		}
+
+		order.Status = "cancelled"
+		order.UpdatedAt = time.Now().Unix()
+
+		return s.purchaseOrderRepo.Update(c, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPurchaseOrderResponse(order), nil
+}
+
+func (s *service) findPurchaseOrder(ctx context.Context, purchaseOrderID int64, isAdmin bool) (*model.PurchaseOrder, error) {
+	order, err := s.purchaseOrderRepo.FindByID(ctx, purchaseOrderID)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil, app.NewError(nil, app.ENotFound, "Purchase order not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, order.Storage.ID, memberID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func toPurchaseOrderResponse(order *model.PurchaseOrder) *purchaseorder.PurchaseOrderResponse {
+	lines := make([]*purchaseorder.LineResponse, 0)
+	for _, line := range order.Lines {
+		lines = append(lines, &purchaseorder.LineResponse{
+			ID:             line.ID,
+			Gsm:            line.Gsm,
+			Width:          line.Width,
+			Io:             line.Io,
+			MaterialNumber: line.MaterialNumber,
+			Expected:       line.Expected,
+			Received:       line.Received,
+			Outstanding:    purchaseorder.Outstanding(line),
+			Over:           purchaseorder.Over(line),
+			Status:         purchaseorder.LineStatus(line),
+		})
+	}
+
+	return &purchaseorder.PurchaseOrderResponse{
+		ID:         order.ID,
+		StorageID:  order.Storage.ID,
+		Number:     order.Number,
+		Supplier:   order.Supplier,
+		ExpectedAt: order.ExpectedAt,
+		Status:     order.Status,
+		MemberID:   order.Member.ID,
+		Lines:      lines,
+		CreatedAt:  order.CreatedAt,
+		UpdatedAt:  order.UpdatedAt,
+	}
+}
@@ -0,0 +1,77 @@
+package purchaseorder
+
+import (
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/pkg/basepaper"
+	"github.com/go-playground/validator/v10"
+)
+
+type ExpectedLine struct {
+	Gsm            int64 `json:"gsm" validate:"required"`
+	Width          int64 `json:"width" validate:"required"`
+	Io             int64 `json:"io" validate:"required"`
+	MaterialNumber int64 `json:"materialNumber" validate:"required"`
+	Expected       int64 `json:"expected" validate:"required,gt=0"`
+}
+
+type CreatePurchaseOrderRequest struct {
+	StorageID  int64          `json:"storageID" validate:"required"`
+	Number     string         `json:"number" validate:"required,lte=50"`
+	Supplier   string         `json:"supplier" validate:"required,lte=255"`
+	ExpectedAt int64          `json:"expectedAt" validate:"required"`
+	Lines      []ExpectedLine `json:"lines" validate:"required,min=1,dive"`
+}
+
+func (r *CreatePurchaseOrderRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type ReceivedLine struct {
+	LineID     int64   `json:"lineID" validate:"required"`
+	Quantity   int64   `json:"quantity" validate:"required,gt=0"`
+	RollWeight float64 `json:"rollWeight" validate:"omitempty,gt=0"`
+	Length     int64   `json:"length" validate:"omitempty,gt=0"`
+	UnitCost   float64 `json:"unitCost" validate:"omitempty,gt=0"`
+}
+
+type ReceivePurchaseOrderRequest struct {
+	ID    int64          `json:"id" validate:"required"`
+	Lines []ReceivedLine `json:"lines" validate:"required,min=1,dive"`
+}
+
+func (r *ReceivePurchaseOrderRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type LineResponse struct {
+	ID             int64  `json:"id"`
+	Gsm            int64  `json:"gsm"`
+	Width          int64  `json:"width"`
+	Io             int64  `json:"io"`
+	MaterialNumber int64  `json:"materialNumber"`
+	Expected       int64  `json:"expected"`
+	Received       int64  `json:"received"`
+	Outstanding    int64  `json:"outstanding"`
+	Over           int64  `json:"over"`
+	Status         string `json:"status"`
+}
+
+type PurchaseOrderResponse struct {
+	ID         int64                             `json:"id"`
+	StorageID  int64                             `json:"storageID"`
+	Number     string                            `json:"number"`
+	Supplier   string                            `json:"supplier"`
+	ExpectedAt int64                             `json:"expectedAt"`
+	Status     string                            `json:"status"`
+	MemberID   int64                             `json:"memberID"`
+	Lines      []*LineResponse                   `json:"lines"`
+	Receipts   []*basepaper.AddBasePaperResponse `json:"receipts,omitempty"`
+	CreatedAt  int64                             `json:"createdAt"`
+	UpdatedAt  int64                             `json:"updatedAt"`
+}
@@ -0,0 +1,170 @@
+package report
+
+import (
+	"sort"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+type AgingBucket struct {
+	Label   string
+	MinDays int64
+	MaxDays int64
+}
+
+var AgingBuckets = []AgingBucket{
+	{Label: "0-7", MinDays: 0, MaxDays: 7},
+	{Label: "8-30", MinDays: 8, MaxDays: 30},
+	{Label: "31-90", MinDays: 31, MaxDays: 90},
+	{Label: ">90", MinDays: 91},
+}
+
+type Layer struct {
+	Quantity  int64
+	EnteredAt int64
+}
+
+func AgeInDays(enteredAt, now int64) int64 {
+	if enteredAt >= now {
+		return 0
+	}
+
+	return (now - enteredAt) / 86400
+}
+
+func BucketOf(days int64) int {
+	for i, bucket := range AgingBuckets {
+		if bucket.MaxDays == 0 || days <= bucket.MaxDays {
+			return i
+		}
+	}
+
+	return len(AgingBuckets) - 1
+}
+
+type Aging struct {
+	rows     map[rowKey][]Layer
+	consumed map[int64][]Layer
+}
+
+func NewAging() *Aging {
+	return &Aging{
+		rows:     make(map[rowKey][]Layer),
+		consumed: make(map[int64][]Layer),
+	}
+}
+
+func (a *Aging) Apply(h *model.History) {
+	var layers []Layer
+
+	if h.FromLocation.Valid {
+		key := historyKey(h, h.FromLocation.String)
+		layers, a.rows[key] = takeOldest(a.rows[key], h.Affected)
+		if !h.ToLocation.Valid {
+			a.consumed[h.ID] = layers
+			return
+		}
+	}
+	if !h.ToLocation.Valid {
+		return
+	}
+
+	if !h.FromLocation.Valid && h.Reference.Valid {
+		layers, a.consumed[h.Reference.Int64] = takeOldest(a.consumed[h.Reference.Int64], h.Affected)
+	}
+	if short := h.Affected - sumLayers(layers); short > 0 {
+		layers = append(layers, Layer{Quantity: short, EnteredAt: h.CreatedAt})
+	}
+
+	key := historyKey(h, h.ToLocation.String)
+	a.rows[key] = append(a.rows[key], layers...)
+	sort.SliceStable(a.rows[key], func(i, j int) bool {
+		return a.rows[key][i].EnteredAt < a.rows[key][j].EnteredAt
+	})
+}
+
+func (a *Aging) Layers(bp *model.BasePaper) []Layer {
+	layers := a.rows[rowKey{
+		StorageID:      bp.Storage.ID,
+		Gsm:            bp.Gsm,
+		Width:          bp.Width,
+		Io:             bp.Io,
+		MaterialNumber: bp.MaterialNumber,
+		Location:       bp.Location,
+	}]
+
+	total := sumLayers(layers)
+	if total > bp.Quantity {
+		_, layers = takeOldest(layers, total-bp.Quantity)
+	}
+
+	res := make([]Layer, len(layers))
+	copy(res, layers)
+	if total < bp.Quantity {
+		res = append([]Layer{{Quantity: bp.Quantity - total, EnteredAt: bp.CreatedAt}}, res...)
+	}
+
+	return res
+}
+
+func historyKey(h *model.History, location string) rowKey {
+	return rowKey{
+		StorageID:      h.Storage.ID,
+		Gsm:            h.BasePaper.Gsm,
+		Width:          h.BasePaper.Width,
+		Io:             h.BasePaper.Io,
+		MaterialNumber: h.BasePaper.MaterialNumber,
+		Location:       location,
+	}
+}
+
+func takeOldest(layers []Layer, quantity int64) ([]Layer, []Layer) {
+	taken := make([]Layer, 0)
+
+	for len(layers) > 0 && quantity > 0 {
+		layer := layers[0]
+		if layer.Quantity > quantity {
+			taken = append(taken, Layer{Quantity: quantity, EnteredAt: layer.EnteredAt})
+			layers = append([]Layer{{Quantity: layer.Quantity - quantity, EnteredAt: layer.EnteredAt}}, layers[1:]...)
+			break
+		}
+
+		taken = append(taken, layer)
+		quantity -= layer.Quantity
+		layers = layers[1:]
+	}
+
+	return taken, layers
+}
+
+func sumLayers(layers []Layer) int64 {
+	sum := int64(0)
+	for _, layer := range layers {
+		sum += layer.Quantity
+	}
+
+	return sum
+}
+
+func NewAgingArea() AgingAreaResponse {
+	area := AgingAreaResponse{
+		Buckets: make([]*AgingBucketResponse, 0),
+	}
+
+	for _, bucket := range AgingBuckets {
+		area.Buckets = append(area.Buckets, &AgingBucketResponse{
+			Label:   bucket.Label,
+			MinDays: bucket.MinDays,
+			MaxDays: bucket.MaxDays,
+		})
+	}
+
+	return area
+}
+
+func (a *AgingAreaResponse) Add(layers []Layer, now int64) {
+	for _, layer := range layers {
+		a.Buckets[BucketOf(AgeInDays(layer.EnteredAt, now))].Quantity += layer.Quantity
+		a.Quantity += layer.Quantity
+	}
+}
@@ -0,0 +1,120 @@
+package report
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const day = int64(86400)
+
+func TestAgeInDays(t *testing.T) {
+	assert.Equal(t, int64(0), AgeInDays(100*day, 100*day+3600))
+	assert.Equal(t, int64(8), AgeInDays(100*day, 108*day))
+	assert.Equal(t, int64(0), AgeInDays(100*day, 99*day))
+}
+
+func TestBucketOf(t *testing.T) {
+	assert.Equal(t, 0, BucketOf(0))
+	assert.Equal(t, 0, BucketOf(7))
+	assert.Equal(t, 1, BucketOf(8))
+	assert.Equal(t, 1, BucketOf(30))
+	assert.Equal(t, 2, BucketOf(31))
+	assert.Equal(t, 2, BucketOf(90))
+	assert.Equal(t, 3, BucketOf(91))
+	assert.Equal(t, 3, BucketOf(400))
+}
+
+func entry(id int64, storageID int64, status string, affected int64, from, to string, reference, createdAt int64) *model.History {
+	h := &model.History{
+		ID:        id,
+		Storage:   model.Storage{ID: storageID},
+		BasePaper: model.BasePaper{Gsm: 80, Width: 1200, Io: 3, MaterialNumber: 4711},
+		Status:    status,
+		Affected:  affected,
+		Reference: sql.NullInt64{Int64: reference, Valid: reference != 0},
+		CreatedAt: createdAt,
+	}
+	if from != "-" {
+		h.FromLocation = sql.NullString{String: from, Valid: true}
+	}
+	if to != "-" {
+		h.ToLocation = sql.NullString{String: to, Valid: true}
+	}
+
+	return h
+}
+
+func row(storageID int64, location string, quantity, createdAt int64) *model.BasePaper {
+	return &model.BasePaper{
+		Storage:        model.Storage{ID: storageID},
+		Gsm:            80,
+		Width:          1200,
+		Io:             3,
+		MaterialNumber: 4711,
+		Location:       location,
+		Quantity:       quantity,
+		CreatedAt:      createdAt,
+	}
+}
+
+func TestAgingCarriesInternalMoves(t *testing.T) {
+	aging := NewAging()
+	aging.Apply(entry(1, 1, "stored", 4, "-", "", 0, 10*day))
+	aging.Apply(entry(2, 1, "stored", 3, "-", "", 0, 20*day))
+	aging.Apply(entry(3, 1, "moved", 5, "", "A1", 0, 30*day))
+	aging.Apply(entry(4, 1, "relocated", 2, "A1", "B1", 0, 40*day))
+
+	assert.Equal(t, []Layer{{Quantity: 2, EnteredAt: 10 * day}}, aging.Layers(row(1, "B1", 2, 40*day)))
+	assert.Equal(t, []Layer{
+		{Quantity: 2, EnteredAt: 10 * day},
+		{Quantity: 1, EnteredAt: 20 * day},
+	}, aging.Layers(row(1, "A1", 3, 30*day)))
+	assert.Equal(t, []Layer{{Quantity: 2, EnteredAt: 20 * day}}, aging.Layers(row(1, "", 2, 10*day)))
+}
+
+func TestAgingCarriesReturnsAndTransfers(t *testing.T) {
+	aging := NewAging()
+	aging.Apply(entry(1, 1, "stored", 6, "-", "A1", 0, 10*day))
+	aging.Apply(entry(2, 1, "delivered", 4, "A1", "-", 0, 20*day))
+	aging.Apply(entry(3, 1, "returned", 1, "-", "A2", 2, 30*day))
+	aging.Apply(entry(4, 1, "transfer_out", 2, "A1", "-", 0, 40*day))
+	aging.Apply(entry(5, 2, "transfer_in", 2, "-", "", 4, 40*day))
+	aging.Apply(entry(6, 1, "adjusted", 3, "-", "A2", 0, 50*day))
+
+	assert.Equal(t, []Layer{
+		{Quantity: 1, EnteredAt: 10 * day},
+		{Quantity: 3, EnteredAt: 50 * day},
+	}, aging.Layers(row(1, "A2", 4, 30*day)))
+	assert.Equal(t, []Layer{{Quantity: 2, EnteredAt: 10 * day}}, aging.Layers(row(2, "", 2, 40*day)))
+}
+
+func TestAgingReconcilesWithRow(t *testing.T) {
+	aging := NewAging()
+	aging.Apply(entry(1, 1, "stored", 3, "-", "A1", 0, 10*day))
+	aging.Apply(entry(2, 1, "stored", 3, "-", "A1", 0, 20*day))
+
+	assert.Equal(t, []Layer{{Quantity: 2, EnteredAt: 20 * day}}, aging.Layers(row(1, "A1", 2, 5*day)))
+	assert.Equal(t, []Layer{
+		{Quantity: 2, EnteredAt: 5 * day},
+		{Quantity: 3, EnteredAt: 10 * day},
+		{Quantity: 3, EnteredAt: 20 * day},
+	}, aging.Layers(row(1, "A1", 8, 5*day)))
+}
+
+func TestAgingAreaAdd(t *testing.T) {
+	area := NewAgingArea()
+	area.Add([]Layer{
+		{Quantity: 3, EnteredAt: 98 * day},
+		{Quantity: 2, EnteredAt: 80 * day},
+		{Quantity: 5, EnteredAt: 1 * day},
+	}, 100*day)
+
+	assert.Equal(t, int64(10), area.Quantity)
+	assert.Equal(t, int64(3), area.Buckets[0].Quantity)
+	assert.Equal(t, int64(2), area.Buckets[1].Quantity)
+	assert.Equal(t, int64(0), area.Buckets[2].Quantity)
+	assert.Equal(t, int64(5), area.Buckets[3].Quantity)
+}
@@ -0,0 +1,89 @@
+package report
+
+import "time"
+
+const (
+	groupingAll      = 7
+	groupingGsm      = 3
+	groupingWidth    = 5
+	groupingMaterial = 6
+)
+
+type Breakdown struct {
+	Value      int64
+	BufferArea int64
+	List       int64
+}
+
+type StockSummary struct {
+	BufferArea        int64
+	List              int64
+	OccupiedLocations int64
+	ByGsm             []*Breakdown
+	ByWidth           []*Breakdown
+	ByMaterial        []*Breakdown
+}
+
+func NewStockSummary() *StockSummary {
+	return &StockSummary{
+		ByGsm:      make([]*Breakdown, 0),
+		ByWidth:    make([]*Breakdown, 0),
+		ByMaterial: make([]*Breakdown, 0),
+	}
+}
+
+func (s *StockSummary) Add(grouping int, value, bufferArea, list, locations int64) {
+	breakdown := &Breakdown{Value: value, BufferArea: bufferArea, List: list}
+
+	switch grouping {
+	case groupingAll:
+		s.BufferArea = bufferArea
+		s.List = list
+		s.OccupiedLocations = locations
+	case groupingGsm:
+		s.ByGsm = append(s.ByGsm, breakdown)
+	case groupingWidth:
+		s.ByWidth = append(s.ByWidth, breakdown)
+	case groupingMaterial:
+		s.ByMaterial = append(s.ByMaterial, breakdown)
+	}
+}
+
+func StartOfDay(now time.Time, loc *time.Location) int64 {
+	year, month, day := now.In(loc).Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, loc).Unix()
+}
+
+func toBreakdownResponses(breakdowns []*Breakdown) []*BreakdownResponse {
+	res := make([]*BreakdownResponse, 0)
+	for _, b := range breakdowns {
+		res = append(res, &BreakdownResponse{
+			Value:      b.Value,
+			BufferArea: b.BufferArea,
+			List:       b.List,
+			Quantity:   b.BufferArea + b.List,
+		})
+	}
+
+	return res
+}
+
+func ToDashboardResponse(storageID int64, summary *StockSummary, today map[string]int64, generatedAt int64) *DashboardResponse {
+	return &DashboardResponse{
+		StorageID:         storageID,
+		BufferArea:        summary.BufferArea,
+		List:              summary.List,
+		Quantity:          summary.BufferArea + summary.List,
+		OccupiedLocations: summary.OccupiedLocations,
+		ByGsm:             toBreakdownResponses(summary.ByGsm),
+		ByWidth:           toBreakdownResponses(summary.ByWidth),
+		ByMaterial:        toBreakdownResponses(summary.ByMaterial),
+		Today: TodayResponse{
+			Stored:    today["stored"],
+			Moved:     today["moved"],
+			Delivered: today["delivered"],
+		},
+		GeneratedAt: generatedAt,
+	}
+}
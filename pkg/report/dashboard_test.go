@@ -0,0 +1,50 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStockSummaryAdd(t *testing.T) {
+	summary := NewStockSummary()
+	summary.Add(7, 0, 10, 25, 4)
+	summary.Add(3, 80, 6, 20, 3)
+	summary.Add(5, 1200, 10, 25, 4)
+	summary.Add(6, 4711, 4, 5, 1)
+
+	assert.Equal(t, int64(10), summary.BufferArea)
+	assert.Equal(t, int64(25), summary.List)
+	assert.Equal(t, int64(4), summary.OccupiedLocations)
+	assert.Equal(t, []*Breakdown{{Value: 80, BufferArea: 6, List: 20}}, summary.ByGsm)
+	assert.Equal(t, []*Breakdown{{Value: 1200, BufferArea: 10, List: 25}}, summary.ByWidth)
+	assert.Equal(t, []*Breakdown{{Value: 4711, BufferArea: 4, List: 5}}, summary.ByMaterial)
+}
+
+func TestStartOfDay(t *testing.T) {
+	loc := time.FixedZone("WIB", 7*3600)
+	now := time.Date(2021, 3, 4, 15, 30, 0, 0, loc)
+
+	assert.Equal(t, time.Date(2021, 3, 4, 0, 0, 0, 0, loc).Unix(), StartOfDay(now, loc))
+}
+
+func TestStartOfDayInLocation(t *testing.T) {
+	loc := time.FixedZone("WIB", 7*3600)
+	now := time.Date(2021, 3, 3, 20, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2021, 3, 4, 0, 0, 0, 0, loc).Unix(), StartOfDay(now, loc))
+	assert.Equal(t, time.Date(2021, 3, 3, 0, 0, 0, 0, time.UTC).Unix(), StartOfDay(now, time.UTC))
+}
+
+func TestToDashboardResponse(t *testing.T) {
+	summary := NewStockSummary()
+	summary.Add(7, 0, 10, 25, 4)
+	summary.Add(3, 80, 10, 25, 4)
+
+	res := ToDashboardResponse(1, summary, map[string]int64{"stored": 5, "delivered": 2}, 100)
+	assert.Equal(t, int64(35), res.Quantity)
+	assert.Equal(t, int64(35), res.ByGsm[0].Quantity)
+	assert.Equal(t, TodayResponse{Stored: 5, Delivered: 2}, res.Today)
+	assert.Len(t, res.ByWidth, 0)
+}
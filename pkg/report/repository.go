@@ -0,0 +1,16 @@
+package report
+
+import (
+	"context"
+
+	"github.com/bagus2x/tjiwi/pkg/basepaper"
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+type Repository interface {
+	SummarizeStock(ctx context.Context, storageID int64) (*StockSummary, error)
+	SumAffectedByStatus(ctx context.Context, storageID, since int64) (map[string]int64, error)
+	SumThroughput(ctx context.Context, req *ThroughputRequest) ([]*ThroughputRow, error)
+	StreamHistories(ctx context.Context, params *basepaper.Params, until int64, fn func(*model.History) error) error
+	StreamHistoriesWithSources(ctx context.Context, params *basepaper.Params, until int64, fn func(*model.History) error) error
+}
@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/basepaper"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/report"
+)
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) report.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) StreamHistories(ctx context.Context, params *basepaper.Params, until int64, fn func(*model.History) error) error {
+	columns, values := historyFilters(params, until)
+	if params.StorageID != nil {
+		values = append(values, *params.StorageID)
+		columns = append(columns, fmt.Sprintf("h.storage_id = $%d", len(values)))
+	}
+
+	return r.streamHistories(ctx, "", columns, values, fn)
+}
+
+func (r *repository) StreamHistoriesWithSources(ctx context.Context, params *basepaper.Params, until int64, fn func(*model.History) error) error {
+	columns, values := historyFilters(params, until)
+	values = append(values, *params.StorageID)
+
+	with := fmt.Sprintf(`
+			WITH RECURSIVE Transfer_Source(storage_id, gsm, width, io, material_number) AS (
+					SELECT
+						o.storage_id, bp.gsm, bp.width, bp.io, bp.material_number
+					FROM
+						History i
+					JOIN
+						History o
+					ON
+						i.reference_id = o.id
+					JOIN
+						Base_Paper bp
+					ON
+						o.base_paper_id = bp.id
+					WHERE
+						i.storage_id = $%[1]d AND i.status = 'transfer_in' AND i.created_at <= $1
+				UNION
+					SELECT
+						o.storage_id, bp.gsm, bp.width, bp.io, bp.material_number
+					FROM
+						Transfer_Source s
+					JOIN
+						History i
+					ON
+						i.storage_id = s.storage_id AND i.status = 'transfer_in' AND i.created_at <= $1
+					JOIN
+						Base_Paper ibp
+					ON
+						i.base_paper_id = ibp.id AND ibp.gsm = s.gsm AND ibp.width = s.width AND ibp.io = s.io AND
+						ibp.material_number = s.material_number
+					JOIN
+						History o
+					ON
+						i.reference_id = o.id
+					JOIN
+						Base_Paper bp
+					ON
+						o.base_paper_id = bp.id
+			)`, len(values))
+
+	columns = append(columns, fmt.Sprintf(
+		"(h.storage_id = $%d OR (h.storage_id, bp.gsm, bp.width, bp.io, bp.material_number) IN (SELECT * FROM Transfer_Source))",
+		len(values),
+	))
+
+	return r.streamHistories(ctx, with, columns, values, fn)
+}
+
+func historyFilters(params *basepaper.Params, until int64) ([]string, []interface{}) {
+	columns := []string{"h.created_at <= $1"}
+	values := []interface{}{until}
+
+	filters := []struct {
+		column string
+		value  *int64
+	}{
+		{"bp.gsm", params.Gsm},
+		{"bp.width", params.Width},
+		{"bp.io", params.Io},
+		{"bp.material_number", params.MaterialNumber},
+	}
+	for _, filter := range filters {
+		if filter.value == nil {
+			continue
+		}
+
+		values = append(values, *filter.value)
+		columns = append(columns, fmt.Sprintf("%s = $%d", filter.column, len(values)))
+	}
+
+	return columns, values
+}
+
+func (r *repository) streamHistories(ctx context.Context, with string, columns []string, values []interface{}, fn func(*model.History) error) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := fmt.Sprintf(`%s
+			SELECT
+				h.id, h.storage_id, h.status, h.affected, h.affected_weight, h.from_location, h.to_location,
+				h.reference_id, h.created_at, bp.id, bp.gsm, bp.width, bp.io, bp.material_number, bp.location
+			FROM
+				History h
+			JOIN
+				Base_Paper bp
+			ON
+				h.base_paper_id = bp.id
+			WHERE
+				%s
+			ORDER BY
+				h.created_at ASC, h.id ASC
+	`, with, strings.Join(columns, " AND "))
+
+	rows, err := tx.QueryContext(ctx, query, values...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var h model.History
+
+		err := rows.Scan(
+			&h.ID,
+			&h.Storage.ID,
+			&h.Status,
+			&h.Affected,
+			&h.AffectedWeight,
+			&h.FromLocation,
+			&h.ToLocation,
+			&h.Reference,
+			&h.CreatedAt,
+			&h.BasePaper.ID,
+			&h.BasePaper.Gsm,
+			&h.BasePaper.Width,
+			&h.BasePaper.Io,
+			&h.BasePaper.MaterialNumber,
+			&h.BasePaper.Location,
+		)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(&h); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *repository) SummarizeStock(ctx context.Context, storageID int64) (*report.StockSummary, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				GROUPING(gsm, width, material_number),
+				COALESCE(gsm, width, material_number, 0),
+				COALESCE(SUM(quantity) FILTER (WHERE location = ''), 0),
+				COALESCE(SUM(quantity) FILTER (WHERE location <> ''), 0),
+				COUNT(DISTINCT location) FILTER (WHERE location <> '')
+			FROM
+				Base_Paper
+			WHERE
+				storage_id = $1 AND is_deleted = FALSE AND quantity > 0
+			GROUP BY
+				GROUPING SETS ((), (gsm), (width), (material_number))
+			ORDER BY
+				1 ASC, 2 ASC
+	`
+
+	rows, err := tx.QueryContext(ctx, query, storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	summary := report.NewStockSummary()
+
+	for rows.Next() {
+		var grouping int
+		var value, bufferArea, list, locations int64
+
+		err := rows.Scan(&grouping, &value, &bufferArea, &list, &locations)
+		if err != nil {
+			return nil, err
+		}
+
+		summary.Add(grouping, value, bufferArea, list, locations)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+func (r *repository) SumAffectedByStatus(ctx context.Context, storageID, since int64) (map[string]int64, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				status, COALESCE(SUM(affected), 0)
+			FROM
+				History
+			WHERE
+				storage_id = $1 AND created_at >= $2
+			GROUP BY
+				status
+	`
+
+	rows, err := tx.QueryContext(ctx, query, storageID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	totals := make(map[string]int64)
+
+	for rows.Next() {
+		var status string
+		var affected int64
+
+		if err := rows.Scan(&status, &affected); err != nil {
+			return nil, err
+		}
+
+		totals[status] = affected
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+func (r *repository) SumThroughput(ctx context.Context, req *report.ThroughputRequest) ([]*report.ThroughputRow, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				h.member_id, p.username, h.status,
+				EXTRACT(EPOCH FROM date_trunc($4, to_timestamp(h.created_at) AT TIME ZONE $5) AT TIME ZONE $5)::BIGINT,
+				SUM(h.affected), COUNT(*)
+			FROM
+				History h
+			JOIN
+				Profile p
+			ON
+				h.member_id = p.id
+			WHERE
+				h.storage_id = $1 AND h.created_at >= $2 AND h.created_at < $3
+			GROUP BY
+				1, 2, 3, 4
+			ORDER BY
+				4 ASC, 1 ASC, 3 ASC
+	`
+
+	rows, err := tx.QueryContext(ctx, query, req.StorageID, req.From, req.To, req.Period, req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	throughput := make([]*report.ThroughputRow, 0)
+
+	for rows.Next() {
+		var row report.ThroughputRow
+
+		err := rows.Scan(&row.MemberID, &row.Username, &row.Status, &row.Period, &row.Affected, &row.Operations)
+		if err != nil {
+			return nil, err
+		}
+
+		throughput = append(throughput, &row)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return throughput, nil
+}
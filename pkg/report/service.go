@@ -0,0 +1,14 @@
+package report
+
+import (
+	"context"
+
+	"github.com/bagus2x/tjiwi/pkg/basepaper"
+)
+
+type Service interface {
+	Aging(ctx context.Context, params *basepaper.Params) (*AgingResponse, error)
+	Snapshot(ctx context.Context, params *basepaper.Params, at int64) (*SnapshotResponse, error)
+	Dashboard(ctx context.Context, req *DashboardRequest) (*DashboardResponse, error)
+	Throughput(ctx context.Context, req *ThroughputRequest) (*ThroughputResponse, error)
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/pkg/basepaper"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/report"
+	stormemb "github.com/bagus2x/tjiwi/pkg/storagemember"
+	"github.com/bagus2x/tjiwi/utils"
+)
+
+type service struct {
+	reportRepo    report.Repository
+	basePaperRepo basepaper.Repository
+	storMembRepo  stormemb.Repository
+}
+
+func New(reportRepo report.Repository, basePaperRepo basepaper.Repository, storMembRepo stormemb.Repository) report.Service {
+	return &service{
+		reportRepo:    reportRepo,
+		basePaperRepo: basePaperRepo,
+		storMembRepo:  storMembRepo,
+	}
+}
+
+func (s *service) Aging(ctx context.Context, params *basepaper.Params) (*report.AgingResponse, error) {
+	if params.StorageID == nil {
+		return nil, app.NewError(nil, app.EBadRequest, "Storage id is required")
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, *params.StorageID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	basePapers := make([]*model.BasePaper, 0)
+	err = s.basePaperRepo.Stream(ctx, params, func(bp *model.BasePaper) error {
+		basePapers = append(basePapers, bp)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+
+	aging := report.NewAging()
+	if len(basePapers) != 0 {
+		spec := basepaper.Params{
+			StorageID:      params.StorageID,
+			Gsm:            params.Gsm,
+			Width:          params.Width,
+			Io:             params.Io,
+			MaterialNumber: params.MaterialNumber,
+		}
+
+		err = s.reportRepo.StreamHistoriesWithSources(ctx, &spec, now, func(h *model.History) error {
+			aging.Apply(h)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := report.AgingResponse{
+		StorageID:   *params.StorageID,
+		GeneratedAt: now,
+		BufferArea:  report.NewAgingArea(),
+		List:        report.NewAgingArea(),
+	}
+
+	for _, bp := range basePapers {
+		layers := aging.Layers(bp)
+		if bp.Location == "" {
+			res.BufferArea.Add(layers, now)
+		} else {
+			res.List.Add(layers, now)
+		}
+	}
+
+	return &res, nil
+}
+
+func (s *service) Snapshot(ctx context.Context, params *basepaper.Params, at int64) (*report.SnapshotResponse, error) {
+	if params.StorageID == nil {
+		return nil, app.NewError(nil, app.EBadRequest, "Storage id is required")
+	}
+	if at <= 0 {
+		return nil, app.NewError(nil, app.EBadRequest, "Timestamp must be positive")
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, *params.StorageID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	snapshot := report.NewSnapshot()
+	err = s.reportRepo.StreamHistories(ctx, params, at, func(h *model.History) error {
+		snapshot.Apply(h)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var location *string
+	if params.Location != nil {
+		code := strings.ToUpper(*params.Location)
+		location = &code
+	}
+
+	basePapers := snapshot.BasePapers(location)
+	res := report.SnapshotResponse{
+		StorageID:  *params.StorageID,
+		At:         at,
+		BufferArea: make([]*report.SnapshotBasePaperResponse, 0),
+		List:       make([]*report.SnapshotBasePaperResponse, 0),
+		Locations:  report.LocationTotals(basePapers),
+	}
+
+	for _, bp := range basePapers {
+		row := &report.SnapshotBasePaperResponse{
+			ID:             bp.ID,
+			StorageID:      bp.Storage.ID,
+			Gsm:            bp.Gsm,
+			Width:          bp.Width,
+			Io:             bp.Io,
+			MaterialNumber: bp.MaterialNumber,
+			Location:       bp.Location,
+			Quantity:       bp.Quantity,
+			Weight:         bp.Weight,
+		}
+
+		if bp.Location == "" {
+			res.BufferArea = append(res.BufferArea, row)
+		} else {
+			res.List = append(res.List, row)
+		}
+	}
+
+	return &res, nil
+}
+
+func (s *service) Dashboard(ctx context.Context, req *report.DashboardRequest) (*report.DashboardResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, req.StorageID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	loc := time.UTC
+	if req.Timezone != "" {
+		loc, err = time.LoadLocation(req.Timezone)
+		if err != nil {
+			return nil, app.NewError(err, app.EBadRequest, "Invalid timezone")
+		}
+	}
+
+	summary, err := s.reportRepo.SummarizeStock(ctx, req.StorageID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	today, err := s.reportRepo.SumAffectedByStatus(ctx, req.StorageID, report.StartOfDay(now, loc))
+	if err != nil {
+		return nil, err
+	}
+
+	return report.ToDashboardResponse(req.StorageID, summary, today, now.Unix()), nil
+}
+
+func (s *service) Throughput(ctx context.Context, req *report.ThroughputRequest) (*report.ThroughputResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, req.StorageID, memberID, true); err != nil {
+		return nil, err
+	}
+
+	if req.Period == "" {
+		req.Period = report.PeriodDay
+	}
+	if req.Timezone == "" {
+		req.Timezone = "UTC"
+	}
+
+	rows, err := s.reportRepo.SumThroughput(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := report.ThroughputResponse{
+		StorageID: req.StorageID,
+		From:      req.From,
+		To:        req.To,
+		Period:    req.Period,
+		Members:   report.GroupThroughput(rows),
+	}
+
+	return &res, nil
+}
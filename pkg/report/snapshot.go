@@ -0,0 +1,126 @@
+package report
+
+import (
+	"sort"
+
+	"github.com/bagus2x/tjiwi/pkg/basepaper"
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+type rowKey struct {
+	StorageID      int64
+	Gsm            int64
+	Width          int64
+	Io             int64
+	MaterialNumber int64
+	Location       string
+}
+
+type Snapshot struct {
+	rows map[rowKey]*model.BasePaper
+}
+
+func NewSnapshot() *Snapshot {
+	return &Snapshot{
+		rows: make(map[rowKey]*model.BasePaper),
+	}
+}
+
+func (s *Snapshot) Apply(h *model.History) {
+	if h.FromLocation.Valid {
+		bp := s.row(h, h.FromLocation.String)
+		bp.Quantity -= h.Affected
+		bp.Weight = basepaper.RoundWeight(bp.Weight - h.AffectedWeight)
+	}
+	if h.ToLocation.Valid {
+		bp := s.row(h, h.ToLocation.String)
+		bp.Quantity += h.Affected
+		bp.Weight = basepaper.RoundWeight(bp.Weight + h.AffectedWeight)
+	}
+}
+
+func (s *Snapshot) row(h *model.History, location string) *model.BasePaper {
+	key := rowKey{
+		StorageID:      h.Storage.ID,
+		Gsm:            h.BasePaper.Gsm,
+		Width:          h.BasePaper.Width,
+		Io:             h.BasePaper.Io,
+		MaterialNumber: h.BasePaper.MaterialNumber,
+		Location:       location,
+	}
+
+	bp, ok := s.rows[key]
+	if !ok {
+		bp = &model.BasePaper{
+			Storage:        model.Storage{ID: key.StorageID},
+			Gsm:            key.Gsm,
+			Width:          key.Width,
+			Io:             key.Io,
+			MaterialNumber: key.MaterialNumber,
+			Location:       location,
+		}
+		s.rows[key] = bp
+	}
+	if bp.ID == 0 && h.BasePaper.Location == location {
+		bp.ID = h.BasePaper.ID
+	}
+
+	return bp
+}
+
+func (s *Snapshot) BasePapers(location *string) []*model.BasePaper {
+	basePapers := make([]*model.BasePaper, 0)
+	for _, bp := range s.rows {
+		if bp.Quantity <= 0 {
+			continue
+		}
+		if location != nil && bp.Location != *location {
+			continue
+		}
+
+		basePapers = append(basePapers, bp)
+	}
+
+	sort.Slice(basePapers, func(i, j int) bool {
+		a, b := basePapers[i], basePapers[j]
+		if a.Location != b.Location {
+			return a.Location < b.Location
+		}
+		if a.Gsm != b.Gsm {
+			return a.Gsm < b.Gsm
+		}
+		if a.Width != b.Width {
+			return a.Width < b.Width
+		}
+		if a.Io != b.Io {
+			return a.Io < b.Io
+		}
+
+		return a.MaterialNumber < b.MaterialNumber
+	})
+
+	return basePapers
+}
+
+func LocationTotals(basePapers []*model.BasePaper) []*SnapshotLocationResponse {
+	totals := make([]*SnapshotLocationResponse, 0)
+	indexes := make(map[string]int)
+
+	for _, bp := range basePapers {
+		if bp.Location == "" {
+			continue
+		}
+
+		i, ok := indexes[bp.Location]
+		if !ok {
+			i = len(totals)
+			indexes[bp.Location] = i
+			totals = append(totals, &SnapshotLocationResponse{Location: bp.Location})
+		}
+
+		totals[i].Quantity += bp.Quantity
+		totals[i].Weight = basepaper.RoundWeight(totals[i].Weight + bp.Weight)
+	}
+
+	return totals
+}
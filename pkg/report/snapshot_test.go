@@ -0,0 +1,58 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotApply(t *testing.T) {
+	buffer := model.BasePaper{ID: 1, Gsm: 80, Width: 1200, Io: 3, MaterialNumber: 100}
+	located := buffer
+	located.ID = 2
+	located.Location = "A-1-1"
+	other := buffer
+	other.ID = 3
+	other.Location = "B-1-1"
+
+	histories := []*model.History{
+		{Storage: model.Storage{ID: 1}, BasePaper: buffer, Affected: 10, AffectedWeight: 100, ToLocation: db.NewNullString("", true)},
+		{Storage: model.Storage{ID: 1}, BasePaper: located, Affected: 6, AffectedWeight: 60, FromLocation: db.NewNullString("", true), ToLocation: db.NewNullString("A-1-1", true)},
+		{Storage: model.Storage{ID: 1}, BasePaper: other, Affected: 2, AffectedWeight: 20, FromLocation: db.NewNullString("A-1-1", true), ToLocation: db.NewNullString("B-1-1", true)},
+		{Storage: model.Storage{ID: 1}, BasePaper: located, Affected: 1, AffectedWeight: 10, FromLocation: db.NewNullString("A-1-1", true)},
+		{Storage: model.Storage{ID: 1}, BasePaper: buffer, Affected: 4, AffectedWeight: 40, FromLocation: db.NewNullString("", true)},
+	}
+
+	snapshot := NewSnapshot()
+	for _, h := range histories {
+		snapshot.Apply(h)
+	}
+
+	basePapers := snapshot.BasePapers(nil)
+	assert.Len(t, basePapers, 2)
+	assert.Equal(t, int64(2), basePapers[0].ID)
+	assert.Equal(t, "A-1-1", basePapers[0].Location)
+	assert.Equal(t, int64(3), basePapers[0].Quantity)
+	assert.Equal(t, 30.0, basePapers[0].Weight)
+	assert.Equal(t, int64(3), basePapers[1].ID)
+	assert.Equal(t, int64(2), basePapers[1].Quantity)
+
+	location := "B-1-1"
+	assert.Len(t, snapshot.BasePapers(&location), 1)
+}
+
+func TestLocationTotals(t *testing.T) {
+	totals := LocationTotals([]*model.BasePaper{
+		{Location: "", Quantity: 4},
+		{Location: "A-1-1", Quantity: 2, Weight: 1.5},
+		{Location: "A-1-1", Quantity: 3, Weight: 2.25},
+		{Location: "B-1-1", Quantity: 1},
+	})
+
+	assert.Equal(t, []*SnapshotLocationResponse{
+		{Location: "A-1-1", Quantity: 5, Weight: 3.75},
+		{Location: "B-1-1", Quantity: 1},
+	}, totals)
+}
@@ -0,0 +1,82 @@
+package report
+
+import "sort"
+
+const (
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+type ThroughputRow struct {
+	MemberID   int64
+	Username   string
+	Status     string
+	Period     int64
+	Affected   int64
+	Operations int64
+}
+
+func GroupThroughput(rows []*ThroughputRow) []*MemberThroughputResponse {
+	members := make([]*MemberThroughputResponse, 0)
+	indexes := make(map[int64]int)
+	statuses := make(map[int64]map[string]*StatusThroughputResponse)
+
+	for _, row := range rows {
+		i, ok := indexes[row.MemberID]
+		if !ok {
+			i = len(members)
+			indexes[row.MemberID] = i
+			statuses[row.MemberID] = make(map[string]*StatusThroughputResponse)
+			members = append(members, &MemberThroughputResponse{
+				MemberID: row.MemberID,
+				Username: row.Username,
+				ByStatus: make([]*StatusThroughputResponse, 0),
+				Entries:  make([]*ThroughputEntryResponse, 0),
+			})
+		}
+
+		member := members[i]
+		member.Affected += row.Affected
+		member.Operations += row.Operations
+		member.Entries = append(member.Entries, &ThroughputEntryResponse{
+			Period:     row.Period,
+			Status:     row.Status,
+			Affected:   row.Affected,
+			Operations: row.Operations,
+		})
+
+		status, ok := statuses[row.MemberID][row.Status]
+		if !ok {
+			status = &StatusThroughputResponse{Status: row.Status}
+			statuses[row.MemberID][row.Status] = status
+			member.ByStatus = append(member.ByStatus, status)
+		}
+
+		status.Affected += row.Affected
+		status.Operations += row.Operations
+	}
+
+	for _, member := range members {
+		sort.SliceStable(member.ByStatus, func(i, j int) bool {
+			return member.ByStatus[i].Status < member.ByStatus[j].Status
+		})
+		sort.SliceStable(member.Entries, func(i, j int) bool {
+			if member.Entries[i].Period != member.Entries[j].Period {
+				return member.Entries[i].Period < member.Entries[j].Period
+			}
+
+			return member.Entries[i].Status < member.Entries[j].Status
+		})
+	}
+
+	sort.SliceStable(members, func(i, j int) bool {
+		if members[i].Affected != members[j].Affected {
+			return members[i].Affected > members[j].Affected
+		}
+
+		return members[i].MemberID < members[j].MemberID
+	})
+
+	return members
+}
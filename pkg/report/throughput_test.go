@@ -0,0 +1,40 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupThroughput(t *testing.T) {
+	members := GroupThroughput([]*ThroughputRow{
+		{MemberID: 1, Username: "budi", Status: "moved", Period: 200, Affected: 4, Operations: 2},
+		{MemberID: 2, Username: "sari", Status: "delivered", Period: 100, Affected: 10, Operations: 1},
+		{MemberID: 1, Username: "budi", Status: "delivered", Period: 100, Affected: 3, Operations: 1},
+		{MemberID: 1, Username: "budi", Status: "moved", Period: 100, Affected: 1, Operations: 1},
+	})
+
+	assert.Len(t, members, 2)
+	assert.Equal(t, int64(2), members[0].MemberID)
+	assert.Equal(t, int64(10), members[0].Affected)
+
+	budi := members[1]
+	assert.Equal(t, "budi", budi.Username)
+	assert.Equal(t, int64(8), budi.Affected)
+	assert.Equal(t, int64(4), budi.Operations)
+	assert.Equal(t, []*StatusThroughputResponse{
+		{Status: "delivered", Affected: 3, Operations: 1},
+		{Status: "moved", Affected: 5, Operations: 3},
+	}, budi.ByStatus)
+	assert.Equal(t, []*ThroughputEntryResponse{
+		{Period: 100, Status: "delivered", Affected: 3, Operations: 1},
+		{Period: 100, Status: "moved", Affected: 1, Operations: 1},
+		{Period: 200, Status: "moved", Affected: 4, Operations: 2},
+	}, budi.Entries)
+}
+
+func TestThroughputRequestValidate(t *testing.T) {
+	assert.NoError(t, (&ThroughputRequest{StorageID: 1, From: 100, To: 200, Period: "week"}).Validate())
+	assert.Error(t, (&ThroughputRequest{StorageID: 1, From: 200, To: 100}).Validate())
+	assert.Error(t, (&ThroughputRequest{StorageID: 1, From: 100, To: 200, Period: "year"}).Validate())
+}
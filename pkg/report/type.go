@@ -0,0 +1,134 @@
+package report
+
+import (
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/go-playground/validator/v10"
+)
+
+type AgingBucketResponse struct {
+	Label    string `json:"label"`
+	MinDays  int64  `json:"minDays"`
+	MaxDays  int64  `json:"maxDays,omitempty"`
+	Quantity int64  `json:"quantity"`
+}
+
+type AgingAreaResponse struct {
+	Quantity int64                  `json:"quantity"`
+	Buckets  []*AgingBucketResponse `json:"buckets"`
+}
+
+type AgingResponse struct {
+	StorageID   int64             `json:"storageID"`
+	GeneratedAt int64             `json:"generatedAt"`
+	BufferArea  AgingAreaResponse `json:"bufferArea"`
+	List        AgingAreaResponse `json:"list"`
+}
+
+type SnapshotBasePaperResponse struct {
+	ID             int64   `json:"id,omitempty"`
+	StorageID      int64   `json:"storageID"`
+	Gsm            int64   `json:"gsm"`
+	Width          int64   `json:"width"`
+	Io             int64   `json:"io"`
+	MaterialNumber int64   `json:"materialNumber"`
+	Location       string  `json:"location,omitempty"`
+	Quantity       int64   `json:"quantity"`
+	Weight         float64 `json:"weight"`
+}
+
+type SnapshotLocationResponse struct {
+	Location string  `json:"location"`
+	Quantity int64   `json:"quantity"`
+	Weight   float64 `json:"weight"`
+}
+
+type SnapshotResponse struct {
+	StorageID  int64                        `json:"storageID"`
+	At         int64                        `json:"at"`
+	BufferArea []*SnapshotBasePaperResponse `json:"bufferArea"`
+	List       []*SnapshotBasePaperResponse `json:"list"`
+	Locations  []*SnapshotLocationResponse  `json:"locations"`
+}
+
+type BreakdownResponse struct {
+	Value      int64 `json:"value"`
+	BufferArea int64 `json:"bufferArea"`
+	List       int64 `json:"list"`
+	Quantity   int64 `json:"quantity"`
+}
+
+type DashboardRequest struct {
+	StorageID int64  `form:"-" validate:"required"`
+	Timezone  string `form:"tz" validate:"omitempty,timezone"`
+}
+
+func (r *DashboardRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type TodayResponse struct {
+	Stored    int64 `json:"stored"`
+	Moved     int64 `json:"moved"`
+	Delivered int64 `json:"delivered"`
+}
+
+type DashboardResponse struct {
+	StorageID         int64                `json:"storageID"`
+	BufferArea        int64                `json:"bufferArea"`
+	List              int64                `json:"list"`
+	Quantity          int64                `json:"quantity"`
+	OccupiedLocations int64                `json:"occupiedLocations"`
+	ByGsm             []*BreakdownResponse `json:"byGsm"`
+	ByWidth           []*BreakdownResponse `json:"byWidth"`
+	ByMaterial        []*BreakdownResponse `json:"byMaterial"`
+	Today             TodayResponse        `json:"today"`
+	GeneratedAt       int64                `json:"generatedAt"`
+}
+
+type ThroughputRequest struct {
+	StorageID int64  `form:"-" validate:"required"`
+	From      int64  `form:"from" validate:"required"`
+	To        int64  `form:"to" validate:"required,gtfield=From"`
+	Period    string `form:"period" validate:"omitempty,oneof=day week month"`
+	Timezone  string `form:"tz" validate:"omitempty,timezone"`
+}
+
+func (r *ThroughputRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type ThroughputEntryResponse struct {
+	Period     int64  `json:"period"`
+	Status     string `json:"status"`
+	Affected   int64  `json:"affected"`
+	Operations int64  `json:"operations"`
+}
+
+type StatusThroughputResponse struct {
+	Status     string `json:"status"`
+	Affected   int64  `json:"affected"`
+	Operations int64  `json:"operations"`
+}
+
+type MemberThroughputResponse struct {
+	MemberID   int64                       `json:"memberID"`
+	Username   string                      `json:"username"`
+	Affected   int64                       `json:"affected"`
+	Operations int64                       `json:"operations"`
+	ByStatus   []*StatusThroughputResponse `json:"byStatus"`
+	Entries    []*ThroughputEntryResponse  `json:"entries"`
+}
+
+type ThroughputResponse struct {
+	StorageID int64                       `json:"storageID"`
+	From      int64                       `json:"from"`
+	To        int64                       `json:"to"`
+	Period    string                      `json:"period"`
+	Members   []*MemberThroughputResponse `json:"members"`
+}
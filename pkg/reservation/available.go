@@ -0,0 +1,88 @@
+package reservation
+
+import "github.com/bagus2x/tjiwi/pkg/model"
+
+func Unreserved(bp *model.BasePaper, reservations []*model.Reservation) int64 {
+	unreserved := bp.Quantity
+
+	for _, r := range reservations {
+		if r.BasePaper.Valid && r.BasePaper.Int64 == bp.ID {
+			unreserved -= r.Quantity
+		}
+	}
+	if unreserved < 0 {
+		return 0
+	}
+
+	return unreserved
+}
+
+func Available(bp *model.BasePaper, specTotal int64, reservations []*model.Reservation, excludeID int64) int64 {
+	rowReserved := int64(0)
+	specReserved := int64(0)
+
+	for _, r := range reservations {
+		if r.ID == excludeID {
+			continue
+		}
+		if r.BasePaper.Valid && r.BasePaper.Int64 == bp.ID {
+			rowReserved += r.Quantity
+		}
+
+		specReserved += r.Quantity
+	}
+
+	available := bp.Quantity - rowReserved
+	if pool := specTotal - specReserved; pool < available {
+		available = pool
+	}
+	if available < 0 {
+		return 0
+	}
+
+	return available
+}
+
+func Pool(basePapers []*model.BasePaper, reservations []*model.Reservation) int64 {
+	pool := int64(0)
+	for _, bp := range basePapers {
+		pool += bp.Quantity
+	}
+	for _, r := range reservations {
+		pool -= r.Quantity
+	}
+	if pool < 0 {
+		return 0
+	}
+
+	return pool
+}
+
+func Carry(bp *model.BasePaper, reservations []*model.Reservation, quantity int64) ([]*model.Reservation, bool) {
+	needed := quantity - Unreserved(bp, reservations)
+	carried := make([]*model.Reservation, 0)
+	moved := int64(0)
+
+	for _, r := range reservations {
+		if needed <= 0 {
+			break
+		}
+		if !r.BasePaper.Valid || r.BasePaper.Int64 != bp.ID || moved+r.Quantity > quantity {
+			continue
+		}
+
+		carried = append(carried, r)
+		moved += r.Quantity
+		needed -= r.Quantity
+	}
+
+	return carried, needed <= 0
+}
+
+func Status(r *model.Reservation, now int64) string {
+	if r.Status == "active" && r.ExpiresAt <= now {
+		return "expired"
+	}
+
+	return r.Status
+}
@@ -0,0 +1,85 @@
+package reservation
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAvailable(t *testing.T) {
+	a := &model.BasePaper{ID: 1, Quantity: 10}
+	b := &model.BasePaper{ID: 2, Quantity: 10}
+	reservations := []*model.Reservation{
+		{ID: 1, BasePaper: sql.NullInt64{Int64: 1, Valid: true}, Quantity: 5},
+	}
+
+	assert.Equal(t, int64(5), Available(a, 20, reservations, 0))
+	assert.Equal(t, int64(10), Available(b, 20, reservations, 0))
+	assert.Equal(t, int64(10), Available(a, 20, reservations, 1))
+
+	reservations = append(reservations, &model.Reservation{ID: 2, Quantity: 8})
+
+	assert.Equal(t, int64(5), Available(a, 20, reservations, 0))
+	assert.Equal(t, int64(7), Available(b, 20, reservations, 0))
+	assert.Equal(t, int64(0), Available(b, 10, reservations, 0))
+}
+
+func TestUnreserved(t *testing.T) {
+	bp := &model.BasePaper{ID: 1, Quantity: 10}
+	reservations := []*model.Reservation{
+		{ID: 1, BasePaper: sql.NullInt64{Int64: 1, Valid: true}, Quantity: 4},
+		{ID: 2, BasePaper: sql.NullInt64{Int64: 2, Valid: true}, Quantity: 3},
+		{ID: 3, Quantity: 5},
+	}
+
+	assert.Equal(t, int64(6), Unreserved(bp, reservations))
+}
+
+func TestPool(t *testing.T) {
+	basePapers := []*model.BasePaper{{ID: 1, Quantity: 10}, {ID: 2, Quantity: 4}}
+	reservations := []*model.Reservation{
+		{ID: 1, BasePaper: sql.NullInt64{Int64: 1, Valid: true}, Quantity: 6},
+		{ID: 2, Quantity: 5},
+	}
+
+	assert.Equal(t, int64(3), Pool(basePapers, reservations))
+
+	reservations = append(reservations, &model.Reservation{ID: 3, Quantity: 5})
+	assert.Equal(t, int64(0), Pool(basePapers, reservations))
+}
+
+func TestCarry(t *testing.T) {
+	bp := &model.BasePaper{ID: 1, Quantity: 10}
+	reservations := []*model.Reservation{
+		{ID: 1, BasePaper: sql.NullInt64{Int64: 1, Valid: true}, Quantity: 6},
+		{ID: 2, BasePaper: sql.NullInt64{Int64: 1, Valid: true}, Quantity: 2},
+		{ID: 3, Quantity: 5},
+	}
+
+	carried, ok := Carry(bp, reservations, 2)
+	assert.True(t, ok)
+	assert.Len(t, carried, 0)
+
+	carried, ok = Carry(bp, reservations, 4)
+	assert.True(t, ok)
+	assert.Len(t, carried, 1)
+	assert.Equal(t, int64(2), carried[0].ID)
+
+	carried, ok = Carry(bp, reservations, 10)
+	assert.True(t, ok)
+	assert.Len(t, carried, 2)
+
+	_, ok = Carry(bp, reservations, 5)
+	assert.False(t, ok)
+}
+
+func TestStatus(t *testing.T) {
+	r := &model.Reservation{Status: "active", ExpiresAt: 100}
+	assert.Equal(t, "active", Status(r, 99))
+	assert.Equal(t, "expired", Status(r, 100))
+
+	r.Status = "released"
+	assert.Equal(t, "released", Status(r, 100))
+}
@@ -0,0 +1,17 @@
+package reservation
+
+import (
+	"context"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, r *model.Reservation) error
+	FindByID(ctx context.Context, reservationID int64) (*model.Reservation, error)
+	FindByStorageID(ctx context.Context, storageID int64) ([]*model.Reservation, error)
+	FindActiveByStorageID(ctx context.Context, storageID, now int64) ([]*model.Reservation, error)
+	FindActiveBySpec(ctx context.Context, spec *model.BasePaper, now int64) ([]*model.Reservation, error)
+	Update(ctx context.Context, r *model.Reservation) error
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+}
@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/reservation"
+	"github.com/sirupsen/logrus"
+)
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) reservation.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Create(ctx context.Context, res *model.Reservation) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			INSERT INTO
+				Reservation
+				(storage_id, base_paper_id, gsm, width, io, material_number, order_name, quantity, status, member_id,
+				expires_at, created_at, updated_at)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			RETURNING
+				id
+	`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		res.Storage.ID,
+		res.BasePaper,
+		res.Gsm,
+		res.Width,
+		res.Io,
+		res.MaterialNumber,
+		res.OrderName,
+		res.Quantity,
+		res.Status,
+		res.Member.ID,
+		res.ExpiresAt,
+		res.CreatedAt,
+		res.UpdatedAt,
+	).Scan(&res.ID)
+
+	return err
+}
+
+func (r *repository) FindByID(ctx context.Context, reservationID int64) (*model.Reservation, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, storage_id, base_paper_id, gsm, width, io, material_number, order_name, quantity, status, member_id,
+				expires_at, created_at, updated_at
+			FROM
+				Reservation
+			WHERE
+				id = $1
+			FOR UPDATE
+	`
+
+	res, err := scanReservation(tx.QueryRowContext(ctx, query, reservationID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, app.NewError(err, app.ENotFound)
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *repository) FindByStorageID(ctx context.Context, storageID int64) ([]*model.Reservation, error) {
+	query := `
+			SELECT
+				id, storage_id, base_paper_id, gsm, width, io, material_number, order_name, quantity, status, member_id,
+				expires_at, created_at, updated_at
+			FROM
+				Reservation
+			WHERE
+				storage_id = $1
+			ORDER BY
+				id DESC
+	`
+
+	return r.findReservations(ctx, query, storageID)
+}
+
+func (r *repository) FindActiveByStorageID(ctx context.Context, storageID, now int64) ([]*model.Reservation, error) {
+	query := `
+			SELECT
+				id, storage_id, base_paper_id, gsm, width, io, material_number, order_name, quantity, status, member_id,
+				expires_at, created_at, updated_at
+			FROM
+				Reservation
+			WHERE
+				storage_id = $1 AND status = 'active' AND expires_at > $2 AND quantity > 0
+	`
+
+	return r.findReservations(ctx, query, storageID, now)
+}
+
+func (r *repository) FindActiveBySpec(ctx context.Context, spec *model.BasePaper, now int64) ([]*model.Reservation, error) {
+	query := `
+			SELECT
+				id, storage_id, base_paper_id, gsm, width, io, material_number, order_name, quantity, status, member_id,
+				expires_at, created_at, updated_at
+			FROM
+				Reservation
+			WHERE
+				storage_id = $1 AND gsm = $2 AND width = $3 AND io = $4 AND material_number = $5 AND status = 'active'
+				AND expires_at > $6 AND quantity > 0
+	`
+
+	return r.findReservations(
+		ctx,
+		query,
+		spec.Storage.ID,
+		spec.Gsm,
+		spec.Width,
+		spec.Io,
+		spec.MaterialNumber,
+		now,
+	)
+}
+
+func (r *repository) Update(ctx context.Context, res *model.Reservation) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			UPDATE
+				Reservation
+			SET
+				base_paper_id = $1,
+				quantity = $2,
+				status = $3,
+				updated_at = $4
+			WHERE
+				id = $5
+	`
+
+	result, err := tx.ExecContext(ctx, query, res.BasePaper, res.Quantity, res.Status, res.UpdatedAt, res.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return app.NewError(nil, app.ENotFound)
+	}
+
+	return nil
+}
+
+func (r *repository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	c := context.WithValue(ctx, db.TransactionKey{}, tx)
+	err = fn(c)
+	if err != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			logrus.Error("Failed to rollback transaction", errTx)
+		}
+		return err
+	}
+
+	if errTX := tx.Commit(); errTX != nil {
+		logrus.Error("Failed to commmit transaction", errTX)
+	}
+
+	return nil
+}
+
+func (r *repository) findReservations(ctx context.Context, query string, args ...interface{}) ([]*model.Reservation, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	reservations := make([]*model.Reservation, 0)
+
+	for rows.Next() {
+		res, err := scanReservation(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		reservations = append(reservations, res)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReservation(row scanner) (*model.Reservation, error) {
+	var res model.Reservation
+
+	err := row.Scan(
+		&res.ID,
+		&res.Storage.ID,
+		&res.BasePaper,
+		&res.Gsm,
+		&res.Width,
+		&res.Io,
+		&res.MaterialNumber,
+		&res.OrderName,
+		&res.Quantity,
+		&res.Status,
+		&res.Member.ID,
+		&res.ExpiresAt,
+		&res.CreatedAt,
+		&res.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,10 @@
+package reservation
+
+import "context"
+
+type Service interface {
+	Create(ctx context.Context, req *CreateReservationRequest) (*ReservationResponse, error)
+	GetByID(ctx context.Context, reservationID int64) (*ReservationResponse, error)
+	GetByStorageID(ctx context.Context, storageID int64) ([]*ReservationResponse, error)
+	Release(ctx context.Context, reservationID int64) (*ReservationResponse, error)
+}
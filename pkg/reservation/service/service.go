@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/basepaper"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/reservation"
+	stormemb "github.com/bagus2x/tjiwi/pkg/storagemember"
+	"github.com/bagus2x/tjiwi/utils"
+)
+
+type service struct {
+	reservationRepo reservation.Repository
+	basePaperRepo   basepaper.Repository
+	storMembRepo    stormemb.Repository
+}
+
+func New(reservationRepo reservation.Repository, basePaperRepo basepaper.Repository, storMembRepo stormemb.Repository) reservation.Service {
+	return &service{
+		reservationRepo: reservationRepo,
+		basePaperRepo:   basePaperRepo,
+		storMembRepo:    storMembRepo,
+	}
+}
+
+func (s *service) Create(ctx context.Context, req *reservation.CreateReservationRequest) (*reservation.ReservationResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	if req.ExpiresAt <= now {
+		return nil, app.NewError(nil, app.EBadRequest, "Expiry must be in the future")
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, req.StorageID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	res := model.Reservation{
+		Storage:        model.Storage{ID: req.StorageID},
+		Gsm:            req.Gsm,
+		Width:          req.Width,
+		Io:             req.Io,
+		MaterialNumber: req.MaterialNumber,
+		OrderName:      req.OrderName,
+		Quantity:       req.Quantity,
+		Status:         "active",
+		Member:         model.User{ID: memberID},
+		ExpiresAt:      req.ExpiresAt,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	err = s.reservationRepo.WithTransaction(ctx, func(c context.Context) error {
+		var bp *model.BasePaper
+
+		if req.BasePaperID != 0 {
+			bp, err = s.basePaperRepo.FindByID(c, req.BasePaperID)
+			if app.ErrorCode(err) == app.ENotFound {
+				return app.NewError(nil, app.ENotFound, "Base paper not found")
+			} else if err != nil {
+				return err
+			}
+			if bp.Storage.ID != req.StorageID {
+				return app.NewError(nil, app.ENotFound, "Base paper not found")
+			}
+			if bp.Location == "" {
+				return app.NewError(nil, app.EBadRequest, "Only base papers in the list can be reserved")
+			}
+
+			res.BasePaper = db.NewNullInt(bp.ID, true)
+			res.Gsm = bp.Gsm
+			res.Width = bp.Width
+			res.Io = bp.Io
+			res.MaterialNumber = bp.MaterialNumber
+		}
+
+		spec := model.BasePaper{
+			Storage:        res.Storage,
+			Gsm:            res.Gsm,
+			Width:          res.Width,
+			Io:             res.Io,
+			MaterialNumber: res.MaterialNumber,
+		}
+
+		specTotal, err := s.basePaperRepo.SumQuantityBySpec(c, &spec)
+		if err != nil {
+			return err
+		}
+
+		reservations, err := s.reservationRepo.FindActiveBySpec(c, &spec, now)
+		if err != nil {
+			return err
+		}
+
+		var available int64
+		if bp != nil {
+			available = reservation.Available(bp, specTotal, reservations, 0)
+		} else {
+			spec.Quantity = specTotal
+			available = reservation.Available(&spec, specTotal, reservations, 0)
+		}
+
+		if req.Quantity > available {
+			return app.NewError(nil, app.EBadRequest, "Quantity exceeds the available quantity")
+		}
+
+		return s.reservationRepo.Create(c, &res)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toReservationResponse(&res, now), nil
+}
+
+func (s *service) GetByID(ctx context.Context, reservationID int64) (*reservation.ReservationResponse, error) {
+	res, err := s.reservationRepo.FindByID(ctx, reservationID)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil, app.NewError(nil, app.ENotFound, "Reservation not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	return toReservationResponse(res, time.Now().Unix()), nil
+}
+
+func (s *service) GetByStorageID(ctx context.Context, storageID int64) ([]*reservation.ReservationResponse, error) {
+	reservations, err := s.reservationRepo.FindByStorageID(ctx, storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	res := make([]*reservation.ReservationResponse, 0)
+
+	for _, r := range reservations {
+		res = append(res, toReservationResponse(r, now))
+	}
+
+	return res, nil
+}
+
+func (s *service) Release(ctx context.Context, reservationID int64) (*reservation.ReservationResponse, error) {
+	var res *model.Reservation
+
+	err := s.reservationRepo.WithTransaction(ctx, func(c context.Context) error {
+		var err error
+
+		res, err = s.reservationRepo.FindByID(c, reservationID)
+		if app.ErrorCode(err) == app.ENotFound {
+			return app.NewError(nil, app.ENotFound, "Reservation not found")
+		} else if err != nil {
+			return err
+		}
+
+		memberID, err := utils.GetUserIDFromCtx(c)
+		if err != nil {
+			return err
+		}
+
+		if err := stormemb.MustBeMember(c, s.storMembRepo, res.Storage.ID, memberID, false); err != nil {
+			return err
+		}
+		if res.Status != "active" {
+			return app.NewError(nil, app.EBadRequest, "Reservation is not active")
+		}
+
+		res.Status = "released"
+		res.UpdatedAt = time.Now().Unix()
+
+		return s.reservationRepo.Update(c, res)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toReservationResponse(res, res.UpdatedAt), nil
+}
+
+func toReservationResponse(r *model.Reservation, now int64) *reservation.ReservationResponse {
+	return &reservation.ReservationResponse{
+		ID:             r.ID,
+		StorageID:      r.Storage.ID,
+		BasePaperID:    r.BasePaper.Int64,
+		Gsm:            r.Gsm,
+		Width:          r.Width,
+		Io:             r.Io,
+		MaterialNumber: r.MaterialNumber,
+		OrderName:      r.OrderName,
+		Quantity:       r.Quantity,
+		Status:         reservation.Status(r, now),
+		MemberID:       r.Member.ID,
+		ExpiresAt:      r.ExpiresAt,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+}
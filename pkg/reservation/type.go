@@ -0,0 +1,42 @@
+package reservation
+
+import (
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/go-playground/validator/v10"
+)
+
+type CreateReservationRequest struct {
+	StorageID      int64  `json:"storageID" validate:"required"`
+	BasePaperID    int64  `json:"basePaperID"`
+	Gsm            int64  `json:"gsm" validate:"required_without=BasePaperID"`
+	Width          int64  `json:"width" validate:"required_without=BasePaperID"`
+	Io             int64  `json:"io" validate:"required_without=BasePaperID"`
+	MaterialNumber int64  `json:"materialNumber" validate:"required_without=BasePaperID"`
+	OrderName      string `json:"orderName" validate:"required,lte=255"`
+	Quantity       int64  `json:"quantity" validate:"required,gt=0"`
+	ExpiresAt      int64  `json:"expiresAt" validate:"required"`
+}
+
+func (r *CreateReservationRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type ReservationResponse struct {
+	ID             int64  `json:"id"`
+	StorageID      int64  `json:"storageID"`
+	BasePaperID    int64  `json:"basePaperID,omitempty"`
+	Gsm            int64  `json:"gsm"`
+	Width          int64  `json:"width"`
+	Io             int64  `json:"io"`
+	MaterialNumber int64  `json:"materialNumber"`
+	OrderName      string `json:"orderName"`
+	Quantity       int64  `json:"quantity"`
+	Status         string `json:"status"`
+	MemberID       int64  `json:"memberID"`
+	ExpiresAt      int64  `json:"expiresAt"`
+	CreatedAt      int64  `json:"createdAt"`
+	UpdatedAt      int64  `json:"updatedAt"`
+}
@@ -0,0 +1,70 @@
+package roll
+
+import (
+	"fmt"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+func Duplicates(serials []string) []string {
+	seen := make(map[string]bool)
+	duplicates := make([]string, 0)
+
+	for _, serial := range serials {
+		if seen[serial] {
+			duplicates = append(duplicates, serial)
+		}
+		seen[serial] = true
+	}
+
+	return duplicates
+}
+
+func Pick(rolls []*model.Roll, serials []string, basePaperID int64) ([]*model.Roll, error) {
+	if duplicates := Duplicates(serials); len(duplicates) != 0 {
+		return nil, app.NewError(nil, app.EBadRequest, fmt.Sprintf("Roll %s is listed more than once", duplicates[0]))
+	}
+
+	bySerial := make(map[string]*model.Roll)
+	for _, r := range rolls {
+		bySerial[r.Serial] = r
+	}
+
+	picked := make([]*model.Roll, 0, len(serials))
+	for _, serial := range serials {
+		r, ok := bySerial[serial]
+		if !ok {
+			return nil, app.NewError(nil, app.ENotFound, fmt.Sprintf("Roll %s not found", serial))
+		}
+		if r.BasePaper.ID != basePaperID || r.Status != "in_stock" {
+			return nil, app.NewError(nil, app.EBadRequest, fmt.Sprintf("Roll %s does not belong to the base paper", serial))
+		}
+
+		picked = append(picked, r)
+	}
+
+	return picked, nil
+}
+
+func TotalWeight(rolls []*model.Roll) (float64, bool) {
+	var total float64
+
+	for _, r := range rolls {
+		if r.Weight <= 0 {
+			return 0, false
+		}
+		total += r.Weight
+	}
+
+	return total, len(rolls) != 0
+}
+
+func Serials(rolls []*model.Roll) []string {
+	serials := make([]string, 0, len(rolls))
+	for _, r := range rolls {
+		serials = append(serials, r.Serial)
+	}
+
+	return serials
+}
@@ -0,0 +1,56 @@
+package roll
+
+import (
+	"testing"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicates(t *testing.T) {
+	assert.Empty(t, Duplicates([]string{"A1", "A2"}))
+	assert.Equal(t, []string{"A1"}, Duplicates([]string{"A1", "A2", "A1"}))
+}
+
+func TestPick(t *testing.T) {
+	rolls := []*model.Roll{
+		{ID: 1, Serial: "A1", BasePaper: model.BasePaper{ID: 10}, Status: "in_stock"},
+		{ID: 2, Serial: "A2", BasePaper: model.BasePaper{ID: 10}, Status: "delivered"},
+		{ID: 3, Serial: "A3", BasePaper: model.BasePaper{ID: 11}, Status: "in_stock"},
+	}
+
+	picked, err := Pick(rolls, []string{"A1"}, 10)
+	assert.NoError(t, err)
+	assert.Len(t, picked, 1)
+	assert.Equal(t, int64(1), picked[0].ID)
+
+	_, err = Pick(rolls, []string{"A1", "A1"}, 10)
+	assert.Equal(t, app.EBadRequest, app.ErrorCode(err))
+
+	_, err = Pick(rolls, []string{"A9"}, 10)
+	assert.Equal(t, app.ENotFound, app.ErrorCode(err))
+
+	_, err = Pick(rolls, []string{"A2"}, 10)
+	assert.Equal(t, app.EBadRequest, app.ErrorCode(err))
+
+	_, err = Pick(rolls, []string{"A3"}, 10)
+	assert.Equal(t, app.EBadRequest, app.ErrorCode(err))
+}
+
+func TestTotalWeight(t *testing.T) {
+	total, ok := TotalWeight([]*model.Roll{{Weight: 950.5}, {Weight: 940}})
+	assert.True(t, ok)
+	assert.Equal(t, 1890.5, total)
+
+	_, ok = TotalWeight([]*model.Roll{{Weight: 950.5}, {Weight: 0}})
+	assert.False(t, ok)
+
+	_, ok = TotalWeight(nil)
+	assert.False(t, ok)
+}
+
+func TestSerials(t *testing.T) {
+	assert.Equal(t, []string{"A1", "A2"}, Serials([]*model.Roll{{Serial: "A1"}, {Serial: "A2"}}))
+	assert.Empty(t, Serials(nil))
+}
@@ -0,0 +1,18 @@
+package roll
+
+import (
+	"context"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, roll *model.Roll) error
+	FindBySerial(ctx context.Context, storageID int64, serial string) (*model.Roll, error)
+	FindBySerials(ctx context.Context, storageID int64, serials []string) ([]*model.Roll, error)
+	FindByBasePaperID(ctx context.Context, basePaperID int64) ([]*model.Roll, error)
+	CountByBasePaperID(ctx context.Context, basePaperID int64) (int64, error)
+	Update(ctx context.Context, roll *model.Roll) error
+	AttachToHistory(ctx context.Context, historyID int64, rolls []*model.Roll) error
+	FindSerialsByHistoryIDs(ctx context.Context, historyIDs []int64) (map[int64][]string, error)
+}
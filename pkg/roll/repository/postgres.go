@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/roll"
+	"github.com/lib/pq"
+)
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) roll.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Create(ctx context.Context, rl *model.Roll) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			INSERT INTO
+				Roll
+				(storage_id, base_paper_id, serial, weight, status, created_at, updated_at)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7)
+			RETURNING
+				id
+	`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		rl.Storage.ID,
+		rl.BasePaper.ID,
+		rl.Serial,
+		rl.Weight,
+		rl.Status,
+		rl.CreatedAt,
+		rl.UpdatedAt,
+	).Scan(&rl.ID)
+
+	return err
+}
+
+func (r *repository) FindBySerial(ctx context.Context, storageID int64, serial string) (*model.Roll, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, storage_id, base_paper_id, serial, weight, status, created_at, updated_at
+			FROM
+				Roll
+			WHERE
+				storage_id = $1 AND serial = $2
+	`
+
+	rl, err := scanRoll(tx.QueryRowContext(ctx, query, storageID, serial))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, app.NewError(err, app.ENotFound)
+		}
+		return nil, err
+	}
+
+	return rl, nil
+}
+
+func (r *repository) FindBySerials(ctx context.Context, storageID int64, serials []string) ([]*model.Roll, error) {
+	query := `
+			SELECT
+				id, storage_id, base_paper_id, serial, weight, status, created_at, updated_at
+			FROM
+				Roll
+			WHERE
+				storage_id = $1 AND serial = ANY($2)
+			FOR UPDATE
+	`
+
+	return r.findRolls(ctx, query, storageID, pq.Array(serials))
+}
+
+func (r *repository) FindByBasePaperID(ctx context.Context, basePaperID int64) ([]*model.Roll, error) {
+	query := `
+			SELECT
+				id, storage_id, base_paper_id, serial, weight, status, created_at, updated_at
+			FROM
+				Roll
+			WHERE
+				base_paper_id = $1 AND status = 'in_stock'
+			ORDER BY
+				serial ASC
+	`
+
+	return r.findRolls(ctx, query, basePaperID)
+}
+
+func (r *repository) CountByBasePaperID(ctx context.Context, basePaperID int64) (int64, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				COUNT(*)
+			FROM
+				Roll
+			WHERE
+				base_paper_id = $1 AND status = 'in_stock'
+	`
+
+	var count int64
+
+	err := tx.QueryRowContext(ctx, query, basePaperID).Scan(&count)
+
+	return count, err
+}
+
+func (r *repository) Update(ctx context.Context, rl *model.Roll) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			UPDATE
+				Roll
+			SET
+				base_paper_id = $1,
+				status = $2,
+				updated_at = $3
+			WHERE
+				id = $4
+	`
+
+	res, err := tx.ExecContext(ctx, query, rl.BasePaper.ID, rl.Status, rl.UpdatedAt, rl.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return app.NewError(nil, app.ENotFound)
+	}
+
+	return nil
+}
+
+func (r *repository) AttachToHistory(ctx context.Context, historyID int64, rolls []*model.Roll) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			INSERT INTO
+				History_Roll
+				(history_id, roll_id)
+			VALUES
+				($1, $2)
+	`
+
+	for _, rl := range rolls {
+		_, err := tx.ExecContext(ctx, query, historyID, rl.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *repository) FindSerialsByHistoryIDs(ctx context.Context, historyIDs []int64) (map[int64][]string, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				hr.history_id, r.serial
+			FROM
+				History_Roll hr
+			JOIN
+				Roll r
+			ON
+				hr.roll_id = r.id
+			WHERE
+				hr.history_id = ANY($1)
+			ORDER BY
+				r.serial ASC
+	`
+
+	rows, err := tx.QueryContext(ctx, query, pq.Array(historyIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	serials := make(map[int64][]string)
+
+	for rows.Next() {
+		var historyID int64
+		var serial string
+
+		if err := rows.Scan(&historyID, &serial); err != nil {
+			return nil, err
+		}
+
+		serials[historyID] = append(serials[historyID], serial)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return serials, nil
+}
+
+func (r *repository) findRolls(ctx context.Context, query string, args ...interface{}) ([]*model.Roll, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	rolls := make([]*model.Roll, 0)
+
+	for rows.Next() {
+		rl, err := scanRoll(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		rolls = append(rolls, rl)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rolls, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRoll(row scanner) (*model.Roll, error) {
+	var rl model.Roll
+
+	err := row.Scan(
+		&rl.ID,
+		&rl.Storage.ID,
+		&rl.BasePaper.ID,
+		&rl.Serial,
+		&rl.Weight,
+		&rl.Status,
+		&rl.CreatedAt,
+		&rl.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rl, nil
+}
@@ -0,0 +1,8 @@
+package roll
+
+import "context"
+
+type Service interface {
+	GetBySerial(ctx context.Context, storageID int64, serial string) (*RollResponse, error)
+	GetByBasePaperID(ctx context.Context, basePaperID int64) ([]*RollResponse, error)
+}
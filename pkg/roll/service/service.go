@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/pkg/basepaper"
+	"github.com/bagus2x/tjiwi/pkg/roll"
+	stormemb "github.com/bagus2x/tjiwi/pkg/storagemember"
+	"github.com/bagus2x/tjiwi/utils"
+)
+
+type service struct {
+	rollRepo      roll.Repository
+	basePaperRepo basepaper.Repository
+	storMembRepo  stormemb.Repository
+}
+
+func New(rollRepo roll.Repository, basePaperRepo basepaper.Repository, storMembRepo stormemb.Repository) roll.Service {
+	return &service{
+		rollRepo:      rollRepo,
+		basePaperRepo: basePaperRepo,
+		storMembRepo:  storMembRepo,
+	}
+}
+
+func (s *service) GetBySerial(ctx context.Context, storageID int64, serial string) (*roll.RollResponse, error) {
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, storageID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	rl, err := s.rollRepo.FindBySerial(ctx, storageID, serial)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil, app.NewError(nil, app.ENotFound, "Roll not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	return roll.ToRollResponse(rl), nil
+}
+
+func (s *service) GetByBasePaperID(ctx context.Context, basePaperID int64) ([]*roll.RollResponse, error) {
+	bp, err := s.basePaperRepo.FindByID(ctx, basePaperID)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil, app.NewError(nil, app.ENotFound, "Base paper not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, bp.Storage.ID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	rolls, err := s.rollRepo.FindByBasePaperID(ctx, basePaperID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*roll.RollResponse, 0)
+	for _, rl := range rolls {
+		res = append(res, roll.ToRollResponse(rl))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,27 @@
+package roll
+
+import "github.com/bagus2x/tjiwi/pkg/model"
+
+type RollResponse struct {
+	ID          int64   `json:"id"`
+	StorageID   int64   `json:"storageID"`
+	BasePaperID int64   `json:"basePaperID"`
+	Serial      string  `json:"serial"`
+	Weight      float64 `json:"weight"`
+	Status      string  `json:"status"`
+	CreatedAt   int64   `json:"createdAt"`
+	UpdatedAt   int64   `json:"updatedAt"`
+}
+
+func ToRollResponse(rl *model.Roll) *RollResponse {
+	return &RollResponse{
+		ID:          rl.ID,
+		StorageID:   rl.Storage.ID,
+		BasePaperID: rl.BasePaper.ID,
+		Serial:      rl.Serial,
+		Weight:      rl.Weight,
+		Status:      rl.Status,
+		CreatedAt:   rl.CreatedAt,
+		UpdatedAt:   rl.UpdatedAt,
+	}
+}
@@ -0,0 +1,65 @@
+package scan
+
+import "github.com/bagus2x/tjiwi/pkg/model"
+
+const (
+	ActionMove    = "move"
+	ActionDeliver = "deliver"
+	ActionCount   = "count"
+)
+
+func BasePaperActions(bp *model.BasePaper, counting bool) []string {
+	actions := make([]string, 0)
+
+	if bp.Quantity > 0 {
+		actions = append(actions, ActionMove)
+		if bp.Location != "" {
+			actions = append(actions, ActionDeliver)
+		}
+	}
+	if counting {
+		actions = append(actions, ActionCount)
+	}
+
+	return actions
+}
+
+func RollActions(rl *model.Roll, bp *model.BasePaper, counting bool) []string {
+	if rl.Status != "in_stock" || bp == nil {
+		return make([]string, 0)
+	}
+
+	return BasePaperActions(bp, counting)
+}
+
+func LocationActions(loc *model.Location, counting bool) []string {
+	actions := make([]string, 0)
+
+	if loc.IsActive {
+		actions = append(actions, ActionMove)
+	}
+	if counting {
+		actions = append(actions, ActionCount)
+	}
+
+	return actions
+}
+
+func OpenStockTake(stockTakes []*model.StockTake, location string) (int64, bool) {
+	for _, st := range stockTakes {
+		if st.Status != "open" {
+			continue
+		}
+		if len(st.Locations) == 0 {
+			return st.ID, true
+		}
+
+		for _, code := range st.Locations {
+			if code == location {
+				return st.ID, true
+			}
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,46 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBasePaperActions(t *testing.T) {
+	assert.Equal(t, []string{"move"}, BasePaperActions(&model.BasePaper{Quantity: 2}, false))
+	assert.Equal(t, []string{"move", "deliver", "count"}, BasePaperActions(&model.BasePaper{Quantity: 2, Location: "A-1-1"}, true))
+	assert.Equal(t, []string{}, BasePaperActions(&model.BasePaper{Location: "A-1-1"}, false))
+}
+
+func TestRollActions(t *testing.T) {
+	bp := model.BasePaper{Quantity: 1, Location: "A-1-1"}
+
+	assert.Equal(t, []string{"move", "deliver"}, RollActions(&model.Roll{Status: "in_stock"}, &bp, false))
+	assert.Equal(t, []string{}, RollActions(&model.Roll{Status: "delivered"}, &bp, true))
+	assert.Equal(t, []string{}, RollActions(&model.Roll{Status: "in_stock"}, nil, true))
+}
+
+func TestLocationActions(t *testing.T) {
+	assert.Equal(t, []string{"move", "count"}, LocationActions(&model.Location{IsActive: true}, true))
+	assert.Equal(t, []string{}, LocationActions(&model.Location{}, false))
+}
+
+func TestOpenStockTake(t *testing.T) {
+	stockTakes := []*model.StockTake{
+		{ID: 1, Status: "approved"},
+		{ID: 2, Status: "open", Locations: []string{"A-1-1"}},
+		{ID: 3, Status: "open"},
+	}
+
+	id, ok := OpenStockTake(stockTakes, "A-1-1")
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), id)
+
+	id, ok = OpenStockTake(stockTakes, "B-1-1")
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), id)
+
+	_, ok = OpenStockTake(stockTakes[:2], "B-1-1")
+	assert.False(t, ok)
+}
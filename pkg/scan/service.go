@@ -0,0 +1,7 @@
+package scan
+
+import "context"
+
+type Service interface {
+	Scan(ctx context.Context, req *ScanRequest) (*ScanResponse, error)
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/pkg/basepaper"
+	"github.com/bagus2x/tjiwi/pkg/label"
+	"github.com/bagus2x/tjiwi/pkg/location"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/roll"
+	"github.com/bagus2x/tjiwi/pkg/scan"
+	"github.com/bagus2x/tjiwi/pkg/stocktake"
+	stormemb "github.com/bagus2x/tjiwi/pkg/storagemember"
+	"github.com/bagus2x/tjiwi/utils"
+)
+
+type service struct {
+	basePaperRepo basepaper.Repository
+	rollRepo      roll.Repository
+	locationRepo  location.Repository
+	stockTakeRepo stocktake.Repository
+	storMembRepo  stormemb.Repository
+}
+
+func New(basePaperRepo basepaper.Repository, rollRepo roll.Repository, locationRepo location.Repository, stockTakeRepo stocktake.Repository, storMembRepo stormemb.Repository) scan.Service {
+	return &service{
+		basePaperRepo: basePaperRepo,
+		rollRepo:      rollRepo,
+		locationRepo:  locationRepo,
+		stockTakeRepo: stockTakeRepo,
+		storMembRepo:  storMembRepo,
+	}
+}
+
+func (s *service) Scan(ctx context.Context, req *scan.ScanRequest) (*scan.ScanResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, req.StorageID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	code, err := label.Parse(req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	stockTakes, err := s.stockTakeRepo.FindByStorageID(ctx, req.StorageID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := scan.ScanResponse{
+		Type: code.Kind,
+		Code: req.Code,
+	}
+
+	switch code.Kind {
+	case label.KindBasePaper:
+		bp, err := s.findBasePaper(ctx, req.StorageID, code.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		stockTakeID, counting := scan.OpenStockTake(stockTakes, bp.Location)
+		res.BasePaper = toBasePaperResponse(bp)
+		res.Actions = scan.BasePaperActions(bp, counting)
+		res.StockTakeID = stockTakeID
+	case label.KindRoll:
+		if code.StorageID != req.StorageID {
+			return nil, app.NewError(nil, app.ENotFound, "Roll not found")
+		}
+
+		rl, err := s.rollRepo.FindBySerial(ctx, req.StorageID, code.Serial)
+		if app.ErrorCode(err) == app.ENotFound {
+			return nil, app.NewError(nil, app.ENotFound, "Roll not found")
+		} else if err != nil {
+			return nil, err
+		}
+
+		res.Roll = roll.ToRollResponse(rl)
+		res.Actions = scan.RollActions(rl, nil, false)
+
+		if rl.Status == "in_stock" {
+			bp, err := s.findBasePaper(ctx, req.StorageID, rl.BasePaper.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			stockTakeID, counting := scan.OpenStockTake(stockTakes, bp.Location)
+			res.BasePaper = toBasePaperResponse(bp)
+			res.Actions = scan.RollActions(rl, bp, counting)
+			res.StockTakeID = stockTakeID
+		}
+	case label.KindLocation:
+		loc, err := s.locationRepo.FindByID(ctx, code.ID)
+		if app.ErrorCode(err) == app.ENotFound {
+			return nil, app.NewError(nil, app.ENotFound, "Location not found")
+		} else if err != nil {
+			return nil, err
+		}
+		if loc.Storage.ID != req.StorageID {
+			return nil, app.NewError(nil, app.ENotFound, "Location not found")
+		}
+
+		stockTakeID, counting := scan.OpenStockTake(stockTakes, loc.Code)
+		res.Location = location.ToLocationResponse(loc)
+		res.Actions = scan.LocationActions(loc, counting)
+		res.StockTakeID = stockTakeID
+	}
+
+	return &res, nil
+}
+
+func (s *service) findBasePaper(ctx context.Context, storageID, basePaperID int64) (*model.BasePaper, error) {
+	bp, err := s.basePaperRepo.FindByID(ctx, basePaperID)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil, app.NewError(nil, app.ENotFound, "Base paper not found")
+	} else if err != nil {
+		return nil, err
+	}
+	if bp.Storage.ID != storageID {
+		return nil, app.NewError(nil, app.ENotFound, "Base paper not found")
+	}
+
+	return bp, nil
+}
+
+func toBasePaperResponse(bp *model.BasePaper) *scan.BasePaperResponse {
+	return &scan.BasePaperResponse{
+		ID:             bp.ID,
+		StorageID:      bp.Storage.ID,
+		Gsm:            bp.Gsm,
+		Width:          bp.Width,
+		Io:             bp.Io,
+		MaterialNumber: bp.MaterialNumber,
+		Location:       bp.Location,
+		Quantity:       bp.Quantity,
+		Weight:         bp.Weight,
+	}
+}
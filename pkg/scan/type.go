@@ -0,0 +1,42 @@
+package scan
+
+import (
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/pkg/location"
+	"github.com/bagus2x/tjiwi/pkg/roll"
+	"github.com/go-playground/validator/v10"
+)
+
+type ScanRequest struct {
+	StorageID int64  `json:"storageID" validate:"required"`
+	Code      string `json:"code" validate:"required,lte=100"`
+}
+
+func (r *ScanRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type BasePaperResponse struct {
+	ID             int64   `json:"id"`
+	StorageID      int64   `json:"storageID"`
+	Gsm            int64   `json:"gsm"`
+	Width          int64   `json:"width"`
+	Io             int64   `json:"io"`
+	MaterialNumber int64   `json:"materialNumber"`
+	Location       string  `json:"location"`
+	Quantity       int64   `json:"quantity"`
+	Weight         float64 `json:"weight"`
+}
+
+type ScanResponse struct {
+	Type        string                     `json:"type"`
+	Code        string                     `json:"code"`
+	BasePaper   *BasePaperResponse         `json:"basePaper,omitempty"`
+	Roll        *roll.RollResponse         `json:"roll,omitempty"`
+	Location    *location.LocationResponse `json:"location,omitempty"`
+	Actions     []string                   `json:"actions"`
+	StockTakeID int64                      `json:"stockTakeID,omitempty"`
+}
@@ -0,0 +1,71 @@
+package stocktake
+
+import (
+	"sort"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+type spec struct {
+	location       string
+	gsm            int64
+	width          int64
+	io             int64
+	materialNumber int64
+}
+
+func Reconcile(basePapers []*model.BasePaper, counts []*model.StockTakeCount) []*Variance {
+	system := make(map[spec]*model.BasePaper)
+	for _, bp := range basePapers {
+		system[spec{bp.Location, bp.Gsm, bp.Width, bp.Io, bp.MaterialNumber}] = bp
+	}
+
+	variances := make(map[spec]*Variance)
+
+	for _, count := range counts {
+		key := spec{count.Location, count.Gsm, count.Width, count.Io, count.MaterialNumber}
+		variance, ok := variances[key]
+		if !ok {
+			variance = &Variance{
+				Location:       count.Location,
+				Gsm:            count.Gsm,
+				Width:          count.Width,
+				Io:             count.Io,
+				MaterialNumber: count.MaterialNumber,
+			}
+			if bp, ok := system[key]; ok {
+				variance.BasePaperID = bp.ID
+				variance.System = bp.Quantity
+			}
+			variances[key] = variance
+		}
+
+		variance.Counted += count.Quantity
+	}
+
+	res := make([]*Variance, 0)
+	for _, variance := range variances {
+		variance.Difference = variance.Counted - variance.System
+		res = append(res, variance)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		a, b := res[i], res[j]
+		if a.Location != b.Location {
+			return a.Location < b.Location
+		}
+		if a.MaterialNumber != b.MaterialNumber {
+			return a.MaterialNumber < b.MaterialNumber
+		}
+		if a.Gsm != b.Gsm {
+			return a.Gsm < b.Gsm
+		}
+		if a.Width != b.Width {
+			return a.Width < b.Width
+		}
+
+		return a.Io < b.Io
+	})
+
+	return res
+}
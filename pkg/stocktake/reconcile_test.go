@@ -0,0 +1,59 @@
+package stocktake
+
+import (
+	"testing"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcile(t *testing.T) {
+	basePapers := []*model.BasePaper{
+		{ID: 1, Location: "A1", Gsm: 80, Width: 1200, Io: 3, MaterialNumber: 4711, Quantity: 10},
+		{ID: 2, Location: "A2", Gsm: 80, Width: 1200, Io: 3, MaterialNumber: 4711, Quantity: 4},
+	}
+	counts := []*model.StockTakeCount{
+		{Location: "A1", Gsm: 80, Width: 1200, Io: 3, MaterialNumber: 4711, Quantity: 8},
+		{Location: "B1", Gsm: 70, Width: 900, Io: 3, MaterialNumber: 4712, Quantity: 2},
+	}
+
+	variances := Reconcile(basePapers, counts)
+	assert.Len(t, variances, 2)
+
+	assert.Equal(t, int64(1), variances[0].BasePaperID)
+	assert.Equal(t, int64(-2), variances[0].Difference)
+
+	assert.Equal(t, int64(0), variances[1].BasePaperID)
+	assert.Equal(t, "B1", variances[1].Location)
+	assert.Equal(t, int64(2), variances[1].Difference)
+}
+
+func TestReconcileExplicitZero(t *testing.T) {
+	basePapers := []*model.BasePaper{
+		{ID: 2, Location: "A2", Gsm: 80, Width: 1200, Io: 3, MaterialNumber: 4711, Quantity: 4},
+	}
+	counts := []*model.StockTakeCount{
+		{Location: "A2", Gsm: 80, Width: 1200, Io: 3, MaterialNumber: 4711, Quantity: 0},
+	}
+
+	variances := Reconcile(basePapers, counts)
+	assert.Len(t, variances, 1)
+	assert.Equal(t, int64(2), variances[0].BasePaperID)
+	assert.Equal(t, int64(0), variances[0].Counted)
+	assert.Equal(t, int64(-4), variances[0].Difference)
+}
+
+func TestReconcileSumsCounters(t *testing.T) {
+	basePapers := []*model.BasePaper{
+		{ID: 1, Location: "A1", Gsm: 80, Width: 1200, Io: 3, MaterialNumber: 4711, Quantity: 10},
+	}
+	counts := []*model.StockTakeCount{
+		{Member: model.User{ID: 1}, Location: "A1", Gsm: 80, Width: 1200, Io: 3, MaterialNumber: 4711, Quantity: 6},
+		{Member: model.User{ID: 2}, Location: "A1", Gsm: 80, Width: 1200, Io: 3, MaterialNumber: 4711, Quantity: 3},
+	}
+
+	variances := Reconcile(basePapers, counts)
+	assert.Len(t, variances, 1)
+	assert.Equal(t, int64(9), variances[0].Counted)
+	assert.Equal(t, int64(-1), variances[0].Difference)
+}
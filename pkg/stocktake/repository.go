@@ -0,0 +1,18 @@
+package stocktake
+
+import (
+	"context"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, st *model.StockTake) error
+	FindByID(ctx context.Context, stockTakeID int64) (*model.StockTake, error)
+	FindByStorageID(ctx context.Context, storageID int64) ([]*model.StockTake, error)
+	UpdateStatus(ctx context.Context, st *model.StockTake) error
+	UpsertCount(ctx context.Context, count *model.StockTakeCount) error
+	FindCounts(ctx context.Context, stockTakeID int64) ([]*model.StockTakeCount, error)
+	FindCountsByMemberID(ctx context.Context, stockTakeID, memberID int64) ([]*model.StockTakeCount, error)
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+}
@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/stocktake"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) stocktake.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Create(ctx context.Context, st *model.StockTake) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			INSERT INTO
+				Stock_Take
+				(storage_id, member_id, locations, status, created_at, updated_at)
+			VALUES
+				($1, $2, $3, $4, $5, $6)
+			RETURNING
+				id
+	`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		st.Storage.ID,
+		st.Member.ID,
+		pq.Array(st.Locations),
+		st.Status,
+		st.CreatedAt,
+		st.UpdatedAt,
+	).Scan(&st.ID)
+
+	return err
+}
+
+func (r *repository) FindByID(ctx context.Context, stockTakeID int64) (*model.StockTake, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, storage_id, member_id, locations, status, created_at, updated_at
+			FROM
+				Stock_Take
+			WHERE
+				id = $1
+			FOR UPDATE
+	`
+
+	var st model.StockTake
+
+	err := tx.QueryRowContext(ctx, query, stockTakeID).Scan(
+		&st.ID,
+		&st.Storage.ID,
+		&st.Member.ID,
+		pq.Array(&st.Locations),
+		&st.Status,
+		&st.CreatedAt,
+		&st.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, app.NewError(err, app.ENotFound)
+		}
+		return nil, err
+	}
+
+	return &st, nil
+}
+
+func (r *repository) FindByStorageID(ctx context.Context, storageID int64) ([]*model.StockTake, error) {
+	query := `
+			SELECT
+				id, storage_id, member_id, locations, status, created_at, updated_at
+			FROM
+				Stock_Take
+			WHERE
+				storage_id = $1
+			ORDER BY
+				id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	stockTakes := make([]*model.StockTake, 0)
+
+	for rows.Next() {
+		var st model.StockTake
+
+		err := rows.Scan(
+			&st.ID,
+			&st.Storage.ID,
+			&st.Member.ID,
+			pq.Array(&st.Locations),
+			&st.Status,
+			&st.CreatedAt,
+			&st.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		stockTakes = append(stockTakes, &st)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stockTakes, nil
+}
+
+func (r *repository) UpdateStatus(ctx context.Context, st *model.StockTake) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			UPDATE
+				Stock_Take
+			SET
+				status = $1,
+				updated_at = $2
+			WHERE
+				id = $3
+	`
+
+	res, err := tx.ExecContext(ctx, query, st.Status, st.UpdatedAt, st.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return app.NewError(nil, app.ENotFound)
+	}
+
+	return nil
+}
+
+func (r *repository) UpsertCount(ctx context.Context, count *model.StockTakeCount) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			INSERT INTO
+				Stock_Take_Count
+				(stock_take_id, member_id, location, gsm, width, io, material_number, quantity, created_at, updated_at)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			ON CONFLICT
+				(stock_take_id, member_id, location, gsm, width, io, material_number)
+			DO UPDATE SET
+				quantity = $8,
+				updated_at = $10
+			RETURNING
+				id, created_at
+	`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		count.StockTake.ID,
+		count.Member.ID,
+		count.Location,
+		count.Gsm,
+		count.Width,
+		count.Io,
+		count.MaterialNumber,
+		count.Quantity,
+		count.CreatedAt,
+		count.UpdatedAt,
+	).Scan(&count.ID, &count.CreatedAt)
+
+	return err
+}
+
+func (r *repository) FindCounts(ctx context.Context, stockTakeID int64) ([]*model.StockTakeCount, error) {
+	query := `
+			SELECT
+				id, stock_take_id, member_id, location, gsm, width, io, material_number, quantity, created_at, updated_at
+			FROM
+				Stock_Take_Count
+			WHERE
+				stock_take_id = $1
+			ORDER BY
+				location ASC, id ASC
+	`
+
+	return r.findCounts(ctx, query, stockTakeID)
+}
+
+func (r *repository) FindCountsByMemberID(ctx context.Context, stockTakeID, memberID int64) ([]*model.StockTakeCount, error) {
+	query := `
+			SELECT
+				id, stock_take_id, member_id, location, gsm, width, io, material_number, quantity, created_at, updated_at
+			FROM
+				Stock_Take_Count
+			WHERE
+				stock_take_id = $1 AND member_id = $2
+			ORDER BY
+				location ASC, id ASC
+	`
+
+	return r.findCounts(ctx, query, stockTakeID, memberID)
+}
+
+func (r *repository) findCounts(ctx context.Context, query string, args ...interface{}) ([]*model.StockTakeCount, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	counts := make([]*model.StockTakeCount, 0)
+
+	for rows.Next() {
+		var count model.StockTakeCount
+
+		err := rows.Scan(
+			&count.ID,
+			&count.StockTake.ID,
+			&count.Member.ID,
+			&count.Location,
+			&count.Gsm,
+			&count.Width,
+			&count.Io,
+			&count.MaterialNumber,
+			&count.Quantity,
+			&count.CreatedAt,
+			&count.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		counts = append(counts, &count)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *repository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	c := context.WithValue(ctx, db.TransactionKey{}, tx)
+	err = fn(c)
+	if err != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			logrus.Error("Failed to rollback transaction", errTx)
+		}
+		return err
+	}
+
+	if errTX := tx.Commit(); errTX != nil {
+		logrus.Error("Failed to commmit transaction", errTX)
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package stocktake
+
+import "context"
+
+type Service interface {
+	Open(ctx context.Context, req *OpenStockTakeRequest) (*StockTakeResponse, error)
+	GetByID(ctx context.Context, stockTakeID int64) (*StockTakeResponse, error)
+	GetByStorageID(ctx context.Context, storageID int64) ([]*StockTakeResponse, error)
+	SubmitCounts(ctx context.Context, req *SubmitCountsRequest) (*CountsResponse, error)
+	GetOwnCounts(ctx context.Context, stockTakeID int64) (*CountsResponse, error)
+	GetVariances(ctx context.Context, stockTakeID int64) (*VariancesResponse, error)
+	Approve(ctx context.Context, stockTakeID int64) (*VariancesResponse, error)
+	Cancel(ctx context.Context, stockTakeID int64) error
+}
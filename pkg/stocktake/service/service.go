@@ -0,0 +1,525 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/basepaper"
+	"github.com/bagus2x/tjiwi/pkg/history"
+	"github.com/bagus2x/tjiwi/pkg/location"
+	"github.com/bagus2x/tjiwi/pkg/material"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/reservation"
+	"github.com/bagus2x/tjiwi/pkg/roll"
+	"github.com/bagus2x/tjiwi/pkg/stocktake"
+	stormemb "github.com/bagus2x/tjiwi/pkg/storagemember"
+	"github.com/bagus2x/tjiwi/pkg/threshold"
+	"github.com/bagus2x/tjiwi/pkg/valuation"
+	"github.com/bagus2x/tjiwi/utils"
+)
+
+type service struct {
+	stockTakeRepo    stocktake.Repository
+	basePaperRepo    basepaper.Repository
+	historyRepo      history.Repository
+	storMembRepo     stormemb.Repository
+	thresholdService threshold.Service
+	locationRepo     location.Repository
+	valuationService valuation.Service
+	rollRepo         roll.Repository
+	materialRepo     material.Repository
+	reservationRepo  reservation.Repository
+}
+
+func New(stockTakeRepo stocktake.Repository, basePaperRepo basepaper.Repository, historyRepo history.Repository, storMembRepo stormemb.Repository, thresholdService threshold.Service, locationRepo location.Repository, valuationService valuation.Service, rollRepo roll.Repository, materialRepo material.Repository, reservationRepo reservation.Repository) stocktake.Service {
+	return &service{
+		stockTakeRepo:    stockTakeRepo,
+		basePaperRepo:    basePaperRepo,
+		historyRepo:      historyRepo,
+		storMembRepo:     storMembRepo,
+		thresholdService: thresholdService,
+		locationRepo:     locationRepo,
+		valuationService: valuationService,
+		rollRepo:         rollRepo,
+		materialRepo:     materialRepo,
+		reservationRepo:  reservationRepo,
+	}
+}
+
+func (s *service) Open(ctx context.Context, req *stocktake.OpenStockTakeRequest) (*stocktake.StockTakeResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, req.StorageID, memberID, true); err != nil {
+		return nil, err
+	}
+
+	locations := make([]string, 0)
+	for _, code := range req.Locations {
+		code = strings.ToUpper(code)
+		if code != "" {
+			if err := s.mustBeActiveLocation(ctx, req.StorageID, code); err != nil {
+				return nil, err
+			}
+		}
+
+		locations = append(locations, code)
+	}
+
+	st := model.StockTake{
+		Storage:   model.Storage{ID: req.StorageID},
+		Member:    model.User{ID: memberID},
+		Locations: locations,
+		Status:    "open",
+		CreatedAt: time.Now().Unix(),
+		UpdatedAt: time.Now().Unix(),
+	}
+
+	err = s.stockTakeRepo.Create(ctx, &st)
+	if err != nil {
+		return nil, err
+	}
+
+	return toStockTakeResponse(&st), nil
+}
+
+func (s *service) GetByID(ctx context.Context, stockTakeID int64) (*stocktake.StockTakeResponse, error) {
+	st, err := s.findStockTake(ctx, stockTakeID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return toStockTakeResponse(st), nil
+}
+
+func (s *service) GetByStorageID(ctx context.Context, storageID int64) ([]*stocktake.StockTakeResponse, error) {
+	stockTakes, err := s.stockTakeRepo.FindByStorageID(ctx, storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*stocktake.StockTakeResponse, 0)
+	for _, st := range stockTakes {
+		res = append(res, toStockTakeResponse(st))
+	}
+
+	return res, nil
+}
+
+func (s *service) SubmitCounts(ctx context.Context, req *stocktake.SubmitCountsRequest) (*stocktake.CountsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	res := stocktake.CountsResponse{
+		StockTakeID: req.StockTakeID,
+		Counts:      make([]*stocktake.CountResponse, 0),
+	}
+
+	err := s.stockTakeRepo.WithTransaction(ctx, func(c context.Context) error {
+		st, err := s.findStockTake(c, req.StockTakeID, false)
+		if err != nil {
+			return err
+		}
+		if st.Status != "open" {
+			return app.NewError(nil, app.EBadRequest, "Stock take is not open")
+		}
+
+		memberID, err := utils.GetUserIDFromCtx(c)
+		if err != nil {
+			return err
+		}
+
+		inScope := make(map[string]bool)
+		for _, location := range st.Locations {
+			inScope[location] = true
+		}
+
+		now := time.Now().Unix()
+
+		for _, count := range req.Counts {
+			location := strings.ToUpper(count.Location)
+			if len(inScope) != 0 && !inScope[location] {
+				return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Location %s is not part of the stock take", location))
+			}
+			if location != "" {
+				if err := s.mustBeActiveLocation(c, st.Storage.ID, location); err != nil {
+					return err
+				}
+			}
+
+			stc := model.StockTakeCount{
+				StockTake:      model.StockTake{ID: st.ID},
+				Member:         model.User{ID: memberID},
+				Location:       location,
+				Gsm:            count.Gsm,
+				Width:          count.Width,
+				Io:             count.Io,
+				MaterialNumber: count.MaterialNumber,
+				Quantity:       count.Quantity,
+				CreatedAt:      now,
+				UpdatedAt:      now,
+			}
+
+			err = s.stockTakeRepo.UpsertCount(c, &stc)
+			if err != nil {
+				return err
+			}
+
+			res.Counts = append(res.Counts, toCountResponse(&stc))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *service) GetOwnCounts(ctx context.Context, stockTakeID int64) (*stocktake.CountsResponse, error) {
+	st, err := s.findStockTake(ctx, stockTakeID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.stockTakeRepo.FindCountsByMemberID(ctx, st.ID, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := stocktake.CountsResponse{
+		StockTakeID: st.ID,
+		Counts:      make([]*stocktake.CountResponse, 0),
+	}
+
+	for _, count := range counts {
+		res.Counts = append(res.Counts, toCountResponse(count))
+	}
+
+	return &res, nil
+}
+
+func (s *service) GetVariances(ctx context.Context, stockTakeID int64) (*stocktake.VariancesResponse, error) {
+	var res *stocktake.VariancesResponse
+
+	err := s.stockTakeRepo.WithTransaction(ctx, func(c context.Context) error {
+		st, err := s.findStockTake(c, stockTakeID, true)
+		if err != nil {
+			return err
+		}
+
+		variances, _, err := s.reconcile(c, st)
+		if err != nil {
+			return err
+		}
+
+		res = &stocktake.VariancesResponse{
+			StockTakeID: st.ID,
+			Status:      st.Status,
+			Variances:   variances,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *service) Approve(ctx context.Context, stockTakeID int64) (*stocktake.VariancesResponse, error) {
+	var res *stocktake.VariancesResponse
+
+	err := s.stockTakeRepo.WithTransaction(ctx, func(c context.Context) error {
+		st, err := s.findStockTake(c, stockTakeID, true)
+		if err != nil {
+			return err
+		}
+		if st.Status != "open" {
+			return app.NewError(nil, app.EBadRequest, "Stock take is not open")
+		}
+
+		variances, basePapers, err := s.reconcile(c, st)
+		if err != nil {
+			return err
+		}
+
+		memberID, err := utils.GetUserIDFromCtx(c)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().Unix()
+
+		for _, variance := range variances {
+			if variance.Difference == 0 {
+				continue
+			}
+
+			var weight float64
+
+			bp, ok := basePapers[variance.BasePaperID]
+			if ok {
+				if err := s.mustNotTrackRolls(c, bp); err != nil {
+					return err
+				}
+				if variance.Difference < 0 {
+					if err := s.mustCoverReservations(c, bp, -variance.Difference); err != nil {
+						return err
+					}
+				}
+
+				counted := basepaper.WeightOf(bp, variance.Counted)
+				weight = math.Abs(basepaper.RoundWeight(counted - bp.Weight))
+
+				bp.Quantity = variance.Counted
+				bp.Weight = counted
+				bp.UpdatedAt = now
+
+				err = s.basePaperRepo.Update(c, bp)
+			} else {
+				if err := s.mustBeActiveMaterial(c, st.Storage.ID, variance.MaterialNumber); err != nil {
+					return err
+				}
+
+				bp = &model.BasePaper{
+					Storage:        st.Storage,
+					Gsm:            variance.Gsm,
+					Width:          variance.Width,
+					Io:             variance.Io,
+					MaterialNumber: variance.MaterialNumber,
+					Quantity:       variance.Counted,
+					Location:       variance.Location,
+					CreatedAt:      now,
+					UpdatedAt:      now,
+				}
+
+				err = s.basePaperRepo.Upsert(c, bp)
+			}
+			if err != nil {
+				return err
+			}
+
+			h := model.History{
+				BasePaper:      model.BasePaper{ID: bp.ID},
+				Storage:        st.Storage,
+				Member:         model.User{ID: memberID},
+				Status:         "adjusted",
+				Affected:       variance.Difference,
+				AffectedWeight: weight,
+				Reason:         db.NewNullString("stock_take", true),
+				Note:           db.NewNullString(fmt.Sprintf("Stock take #%d", st.ID), true),
+				CreatedAt:      now,
+			}
+
+			if variance.Difference < 0 {
+				h.Affected = -variance.Difference
+				h.FromLocation = db.NewNullString(variance.Location, true)
+			} else {
+				h.ToLocation = db.NewNullString(variance.Location, true)
+			}
+
+			err = s.historyRepo.Create(c, &h)
+			if err != nil {
+				return err
+			}
+
+			if variance.Difference < 0 {
+				_, err = s.valuationService.Issue(c, bp, -variance.Difference)
+			} else {
+				err = s.valuationService.Restore(c, bp, variance.Difference, h.ID)
+			}
+			if err != nil {
+				return err
+			}
+
+			err = s.thresholdService.Check(c, bp)
+			if err != nil {
+				return err
+			}
+		}
+
+		st.Status = "approved"
+		st.UpdatedAt = now
+
+		err = s.stockTakeRepo.UpdateStatus(c, st)
+		if err != nil {
+			return err
+		}
+
+		res = &stocktake.VariancesResponse{
+			StockTakeID: st.ID,
+			Status:      st.Status,
+			Variances:   variances,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *service) Cancel(ctx context.Context, stockTakeID int64) error {
+	err := s.stockTakeRepo.WithTransaction(ctx, func(c context.Context) error {
+		st, err := s.findStockTake(c, stockTakeID, true)
+		if err != nil {
+			return err
+		}
+		if st.Status != "open" {
+			return app.NewError(nil, app.EBadRequest, "Stock take is not open")
+		}
+
+		st.Status = "cancelled"
+		st.UpdatedAt = time.Now().Unix()
+
+		return s.stockTakeRepo.UpdateStatus(c, st)
+	})
+
+	return err
+}
+
+func (s *service) findStockTake(ctx context.Context, stockTakeID int64, isAdmin bool) (*model.StockTake, error) {
+	st, err := s.stockTakeRepo.FindByID(ctx, stockTakeID)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil, app.NewError(nil, app.ENotFound, "Stock take not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, st.Storage.ID, memberID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	return st, nil
+}
+
+func (s *service) reconcile(ctx context.Context, st *model.StockTake) ([]*stocktake.Variance, map[int64]*model.BasePaper, error) {
+	basePapers, err := s.basePaperRepo.FindByLocations(ctx, st.Storage.ID, st.Locations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	counts, err := s.stockTakeRepo.FindCounts(ctx, st.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byID := make(map[int64]*model.BasePaper)
+	for _, bp := range basePapers {
+		byID[bp.ID] = bp
+	}
+
+	return stocktake.Reconcile(basePapers, counts), byID, nil
+}
+
+func (s *service) mustNotTrackRolls(ctx context.Context, bp *model.BasePaper) error {
+	count, err := s.rollRepo.CountByBasePaperID(ctx, bp.ID)
+	if err != nil {
+		return err
+	}
+	if count != 0 {
+		return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Base paper at %s is tracked per roll, resolve its variance by serial", bp.Location))
+	}
+
+	return nil
+}
+
+func (s *service) mustCoverReservations(ctx context.Context, bp *model.BasePaper, decrease int64) error {
+	reservations, err := s.reservationRepo.FindActiveBySpec(ctx, bp, time.Now().Unix())
+	if err != nil {
+		return err
+	}
+	if len(reservations) == 0 {
+		return nil
+	}
+
+	specTotal, err := s.basePaperRepo.SumQuantityBySpec(ctx, bp)
+	if err != nil {
+		return err
+	}
+	if decrease > reservation.Available(bp, specTotal, reservations, 0) {
+		return app.NewError(nil, app.Econflict, fmt.Sprintf("Counted quantity at %s falls below the reserved quantity, release the reservation first", bp.Location))
+	}
+
+	return nil
+}
+
+func (s *service) mustBeActiveMaterial(ctx context.Context, storageID, number int64) error {
+	m, err := s.materialRepo.FindByNumber(ctx, storageID, number)
+	if app.ErrorCode(err) == app.ENotFound {
+		return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Material %d is not in the catalog", number))
+	} else if err != nil {
+		return err
+	}
+	if !m.IsActive {
+		return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Material %d is inactive", number))
+	}
+
+	return nil
+}
+
+func (s *service) mustBeActiveLocation(ctx context.Context, storageID int64, code string) error {
+	loc, err := s.locationRepo.FindByCode(ctx, storageID, code)
+	if app.ErrorCode(err) == app.ENotFound {
+		return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Location %s is not registered", code))
+	} else if err != nil {
+		return err
+	}
+	if !loc.IsActive {
+		return app.NewError(nil, app.EBadRequest, fmt.Sprintf("Location %s is inactive", code))
+	}
+
+	return nil
+}
+
+func toStockTakeResponse(st *model.StockTake) *stocktake.StockTakeResponse {
+	return &stocktake.StockTakeResponse{
+		ID:        st.ID,
+		StorageID: st.Storage.ID,
+		MemberID:  st.Member.ID,
+		Locations: st.Locations,
+		Status:    st.Status,
+		CreatedAt: st.CreatedAt,
+		UpdatedAt: st.UpdatedAt,
+	}
+}
+
+func toCountResponse(count *model.StockTakeCount) *stocktake.CountResponse {
+	return &stocktake.CountResponse{
+		ID:             count.ID,
+		Location:       count.Location,
+		Gsm:            count.Gsm,
+		Width:          count.Width,
+		Io:             count.Io,
+		MaterialNumber: count.MaterialNumber,
+		Quantity:       count.Quantity,
+		UpdatedAt:      count.UpdatedAt,
+	}
+}
@@ -0,0 +1,83 @@
+package stocktake
+
+import (
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/go-playground/validator/v10"
+)
+
+type OpenStockTakeRequest struct {
+	StorageID int64    `json:"storageID" validate:"required"`
+	Locations []string `json:"locations" validate:"dive,lte=10"`
+}
+
+func (r *OpenStockTakeRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type StockTakeResponse struct {
+	ID        int64    `json:"id"`
+	StorageID int64    `json:"storageID"`
+	MemberID  int64    `json:"memberID"`
+	Locations []string `json:"locations"`
+	Status    string   `json:"status"`
+	CreatedAt int64    `json:"createdAt"`
+	UpdatedAt int64    `json:"updatedAt"`
+}
+
+type Count struct {
+	Location       string `json:"location" validate:"lte=10"`
+	Gsm            int64  `json:"gsm" validate:"required"`
+	Width          int64  `json:"width" validate:"required"`
+	Io             int64  `json:"io" validate:"required"`
+	MaterialNumber int64  `json:"materialNumber" validate:"required"`
+	Quantity       int64  `json:"quantity" validate:"gte=0"`
+}
+
+type SubmitCountsRequest struct {
+	StockTakeID int64   `json:"stockTakeID" validate:"required"`
+	Counts      []Count `json:"counts" validate:"required,min=1,dive"`
+}
+
+func (r *SubmitCountsRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type CountResponse struct {
+	ID             int64  `json:"id"`
+	Location       string `json:"location"`
+	Gsm            int64  `json:"gsm"`
+	Width          int64  `json:"width"`
+	Io             int64  `json:"io"`
+	MaterialNumber int64  `json:"materialNumber"`
+	Quantity       int64  `json:"quantity"`
+	UpdatedAt      int64  `json:"updatedAt"`
+}
+
+type CountsResponse struct {
+	StockTakeID int64            `json:"stockTakeID"`
+	Counts      []*CountResponse `json:"counts"`
+}
+
+type Variance struct {
+	BasePaperID    int64  `json:"basePaperID,omitempty"`
+	Location       string `json:"location"`
+	Gsm            int64  `json:"gsm"`
+	Width          int64  `json:"width"`
+	Io             int64  `json:"io"`
+	MaterialNumber int64  `json:"materialNumber"`
+	System         int64  `json:"system"`
+	Counted        int64  `json:"counted"`
+	Difference     int64  `json:"difference"`
+}
+
+type VariancesResponse struct {
+	StockTakeID int64       `json:"stockTakeID"`
+	Status      string      `json:"status"`
+	Variances   []*Variance `json:"variances"`
+}
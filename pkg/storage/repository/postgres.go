@@ -50,8 +50,8 @@ func (r *repository) Create(ctx context.Context, st *model.Storage) error {
 func (r *repository) FindByID(ctx context.Context, storageID int64) (*model.Storage, error) {
 	query := `
 			SELECT
-				s.id, p.id, p.photo, p.username, p.email, s.name, s.description, s.created_at,
-				s.updated_at
+				s.id, p.id, p.photo, p.username, p.email, s.name, s.description, s.enforce_fifo,
+				s.valuation_method, s.created_at, s.updated_at
 			FROM
 				Storage s
 			JOIN
@@ -72,6 +72,8 @@ func (r *repository) FindByID(ctx context.Context, storageID int64) (*model.Stor
 		&s.Supervisor.Email,
 		&s.Name,
 		&s.Description,
+		&s.EnforceFifo,
+		&s.ValuationMethod,
 		&s.CreatedAt,
 		&s.UpdatedAt,
 	)
@@ -89,7 +91,7 @@ func (r *repository) FindByID(ctx context.Context, storageID int64) (*model.Stor
 func (r *repository) FindBySupervisorID(ctx context.Context, supervisorID int64) ([]*model.Storage, error) {
 	query := `
 			SELECT
-				id, supervisor_id, name, description, is_deleted, created_at, updated_at
+				id, supervisor_id, name, description, enforce_fifo, valuation_method, is_deleted, created_at, updated_at
 			FROM
 				Storage
 			WHERE
@@ -115,6 +117,8 @@ func (r *repository) FindBySupervisorID(ctx context.Context, supervisorID int64)
 			&s.Supervisor.ID,
 			&s.Name,
 			&s.Description,
+			&s.EnforceFifo,
+			&s.ValuationMethod,
 			&s.IsDeleted,
 			&s.CreatedAt,
 			&s.UpdatedAt,
@@ -161,6 +165,60 @@ func (r *repository) Update(ctx context.Context, st *model.Storage) error {
 	return nil
 }
 
+func (r *repository) UpdateEnforceFifo(ctx context.Context, st *model.Storage) error {
+	query := `
+			UPDATE
+				Storage
+			SET
+				enforce_fifo = $1,
+				updated_at = $2
+			WHERE
+				id = $3 AND is_deleted = FALSE
+	`
+
+	res, err := r.db.ExecContext(ctx, query, st.EnforceFifo, st.UpdatedAt, st.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return app.NewError(nil, app.ENotFound)
+	}
+
+	return nil
+}
+
+func (r *repository) UpdateValuationMethod(ctx context.Context, st *model.Storage) error {
+	query := `
+			UPDATE
+				Storage
+			SET
+				valuation_method = $1,
+				updated_at = $2
+			WHERE
+				id = $3 AND is_deleted = FALSE
+	`
+
+	res, err := r.db.ExecContext(ctx, query, st.ValuationMethod, st.UpdatedAt, st.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return app.NewError(nil, app.ENotFound)
+	}
+
+	return nil
+}
+
 func (r *repository) SoftDelete(ctx context.Context, storageID int64, isDeleted bool) error {
 	query := `
 			UPDATE
@@ -9,5 +9,7 @@ type Service interface {
 	GetByID(ctx context.Context, storageID int64) (*FindStorageResponse, error)
 	GetBySupervisorID(ctx context.Context, storageID int64) ([]*FindStorageResponse, error)
 	Update(ctx context.Context, req *UpdateStorageRequest) (*UpdateStorageResponse, error)
+	SetEnforceFifo(ctx context.Context, req *SetEnforceFifoRequest) (*SetEnforceFifoResponse, error)
+	SetValuationMethod(ctx context.Context, req *SetValuationMethodRequest) (*SetValuationMethodResponse, error)
 	Delete(ctx context.Context, storageID int64) error
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/bagus2x/tjiwi/pkg/model"
 	"github.com/bagus2x/tjiwi/pkg/storage"
 	stormemb "github.com/bagus2x/tjiwi/pkg/storagemember"
+	"github.com/bagus2x/tjiwi/utils"
 )
 
 type service struct {
@@ -89,10 +90,12 @@ func (s *service) GetByID(ctx context.Context, storageID int64) (*storage.FindSt
 			Username: st.Supervisor.Username,
 			Email:    st.Supervisor.Email,
 		},
-		Name:        st.Name,
-		Description: st.Description.String,
-		CreatedAt:   st.CreatedAt,
-		UpdatedAt:   st.UpdatedAt,
+		Name:            st.Name,
+		Description:     st.Description.String,
+		EnforceFifo:     st.EnforceFifo,
+		ValuationMethod: st.ValuationMethod,
+		CreatedAt:       st.CreatedAt,
+		UpdatedAt:       st.UpdatedAt,
 	}
 
 	return &res, nil
@@ -112,10 +115,12 @@ func (s *service) GetBySupervisorID(ctx context.Context, supervisorID int64) ([]
 			Supervisor: storage.Supervisor{
 				ID: s.Supervisor.ID,
 			},
-			Name:        s.Name,
-			Description: s.Description.String,
-			CreatedAt:   s.CreatedAt,
-			UpdatedAt:   s.UpdatedAt,
+			Name:            s.Name,
+			Description:     s.Description.String,
+			EnforceFifo:     s.EnforceFifo,
+			ValuationMethod: s.ValuationMethod,
+			CreatedAt:       s.CreatedAt,
+			UpdatedAt:       s.UpdatedAt,
 		})
 	}
 
@@ -148,6 +153,78 @@ func (s *service) Update(ctx context.Context, req *storage.UpdateStorageRequest)
 	return &res, nil
 }
 
+func (s *service) SetEnforceFifo(ctx context.Context, req *storage.SetEnforceFifoRequest) (*storage.SetEnforceFifoResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, req.StorageID, memberID, true); err != nil {
+		return nil, err
+	}
+
+	st := model.Storage{
+		ID:          req.StorageID,
+		EnforceFifo: req.EnforceFifo,
+		UpdatedAt:   time.Now().Unix(),
+	}
+
+	err = s.storageRepo.UpdateEnforceFifo(ctx, &st)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil, app.NewError(nil, app.ENotFound, "Storage not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	res := storage.SetEnforceFifoResponse{
+		ID:          st.ID,
+		EnforceFifo: st.EnforceFifo,
+		UpdatedAt:   st.UpdatedAt,
+	}
+
+	return &res, nil
+}
+
+func (s *service) SetValuationMethod(ctx context.Context, req *storage.SetValuationMethodRequest) (*storage.SetValuationMethodResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, req.StorageID, memberID, true); err != nil {
+		return nil, err
+	}
+
+	st := model.Storage{
+		ID:              req.StorageID,
+		ValuationMethod: req.Method,
+		UpdatedAt:       time.Now().Unix(),
+	}
+
+	err = s.storageRepo.UpdateValuationMethod(ctx, &st)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil, app.NewError(nil, app.ENotFound, "Storage not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	res := storage.SetValuationMethodResponse{
+		ID:              st.ID,
+		ValuationMethod: st.ValuationMethod,
+		UpdatedAt:       st.UpdatedAt,
+	}
+
+	return &res, nil
+}
+
 func (s *service) Delete(ctx context.Context, storageID int64) error {
 	err := s.storageRepo.SoftDelete(ctx, storageID, true)
 	if app.ErrorCode(err) == app.ENotFound {
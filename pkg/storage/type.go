@@ -35,12 +35,14 @@ type CreateStorageResponse struct {
 }
 
 type FindStorageResponse struct {
-	ID          int64      `json:"id"`
-	Supervisor  Supervisor `json:"supervisor"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	CreatedAt   int64      `json:"createdAt"`
-	UpdatedAt   int64      `json:"updatedAt"`
+	ID              int64      `json:"id"`
+	Supervisor      Supervisor `json:"supervisor"`
+	Name            string     `json:"name"`
+	Description     string     `json:"description"`
+	EnforceFifo     bool       `json:"enforceFifo"`
+	ValuationMethod string     `json:"valuationMethod"`
+	CreatedAt       int64      `json:"createdAt"`
+	UpdatedAt       int64      `json:"updatedAt"`
 }
 
 type UpdateStorageRequest struct {
@@ -62,3 +64,39 @@ type UpdateStorageResponse struct {
 	Description string `json:"description"`
 	UpdatedAt   int64  `json:"updatedAt"`
 }
+
+type SetEnforceFifoRequest struct {
+	StorageID   int64 `json:"storageID" validate:"required"`
+	EnforceFifo bool  `json:"enforceFifo"`
+}
+
+func (r *SetEnforceFifoRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type SetEnforceFifoResponse struct {
+	ID          int64 `json:"id"`
+	EnforceFifo bool  `json:"enforceFifo"`
+	UpdatedAt   int64 `json:"updatedAt"`
+}
+
+type SetValuationMethodRequest struct {
+	StorageID int64  `json:"storageID" validate:"required"`
+	Method    string `json:"method" validate:"required,oneof=fifo average"`
+}
+
+func (r *SetValuationMethodRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type SetValuationMethodResponse struct {
+	ID              int64  `json:"id"`
+	ValuationMethod string `json:"valuationMethod"`
+	UpdatedAt       int64  `json:"updatedAt"`
+}
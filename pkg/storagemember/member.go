@@ -0,0 +1,24 @@
+package storagemember
+
+import (
+	"context"
+
+	"github.com/bagus2x/tjiwi/app"
+)
+
+func MustBeMember(ctx context.Context, repo Repository, storageID, memberID int64, isAdmin bool) error {
+	sm, err := repo.FindByStorageIDAndUserID(ctx, storageID, memberID)
+	if app.ErrorCode(err) == app.ENotFound {
+		return app.NewError(nil, app.EForbidden, "User is not a member of the storage")
+	} else if err != nil {
+		return err
+	}
+	if !sm.IsActive {
+		return app.NewError(nil, app.EForbidden, "User status is inactive")
+	}
+	if isAdmin && !sm.IsAdmin {
+		return app.NewError(nil, app.EForbidden, "User status is not an admin")
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package storagemember
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRepo struct {
+	Repository
+	members map[int64]*model.StorageMember
+}
+
+func (r *fakeRepo) FindByStorageIDAndUserID(ctx context.Context, storageID, userID int64) (*model.StorageMember, error) {
+	sm, ok := r.members[userID]
+	if !ok || sm.Storage.ID != storageID {
+		return nil, app.NewError(nil, app.ENotFound)
+	}
+
+	return sm, nil
+}
+
+func TestMustBeMember(t *testing.T) {
+	repo := &fakeRepo{members: map[int64]*model.StorageMember{
+		1: {Storage: model.Storage{ID: 1}, IsActive: true, IsAdmin: true},
+		2: {Storage: model.Storage{ID: 1}, IsActive: true},
+		3: {Storage: model.Storage{ID: 1}},
+	}}
+
+	assert.NoError(t, MustBeMember(context.Background(), repo, 1, 1, true))
+	assert.NoError(t, MustBeMember(context.Background(), repo, 1, 2, false))
+	assert.Equal(t, app.EForbidden, app.ErrorCode(MustBeMember(context.Background(), repo, 1, 2, true)))
+	assert.Equal(t, app.EForbidden, app.ErrorCode(MustBeMember(context.Background(), repo, 1, 3, false)))
+	assert.Equal(t, app.EForbidden, app.ErrorCode(MustBeMember(context.Background(), repo, 2, 1, false)))
+}
@@ -0,0 +1,20 @@
+package threshold
+
+const (
+	ActionNone    = "none"
+	ActionRaise   = "raise"
+	ActionResolve = "resolve"
+)
+
+func Evaluate(minimum, quantity int64, hasOpenAlert bool) string {
+	low := quantity < minimum
+
+	if low && !hasOpenAlert {
+		return ActionRaise
+	}
+	if !low && hasOpenAlert {
+		return ActionResolve
+	}
+
+	return ActionNone
+}
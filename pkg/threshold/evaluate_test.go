@@ -0,0 +1,15 @@
+package threshold
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	assert.Equal(t, ActionRaise, Evaluate(10, 9, false))
+	assert.Equal(t, ActionNone, Evaluate(10, 9, true))
+	assert.Equal(t, ActionNone, Evaluate(10, 10, false))
+	assert.Equal(t, ActionResolve, Evaluate(10, 10, true))
+	assert.Equal(t, ActionNone, Evaluate(0, 0, false))
+}
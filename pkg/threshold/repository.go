@@ -0,0 +1,19 @@
+package threshold
+
+import (
+	"context"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+type Repository interface {
+	Upsert(ctx context.Context, t *model.Threshold) error
+	FindByID(ctx context.Context, thresholdID int64) (*model.Threshold, error)
+	FindBySpec(ctx context.Context, spec *model.BasePaper) (*model.Threshold, error)
+	FindByStorageID(ctx context.Context, storageID int64) ([]*model.Threshold, error)
+	Delete(ctx context.Context, thresholdID int64) error
+	CreateAlert(ctx context.Context, alert *model.Alert) error
+	FindOpenAlert(ctx context.Context, thresholdID int64) (*model.Alert, error)
+	FindOpenAlertsByStorageID(ctx context.Context, storageID int64) ([]*model.Alert, error)
+	UpdateAlert(ctx context.Context, alert *model.Alert) error
+}
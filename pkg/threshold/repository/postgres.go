@@ -0,0 +1,336 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/threshold"
+)
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) threshold.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Upsert(ctx context.Context, t *model.Threshold) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			INSERT INTO
+				Threshold
+				(storage_id, gsm, width, material_number, minimum, member_id, created_at, updated_at)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT
+				(storage_id, gsm, width, material_number)
+			DO UPDATE SET
+				minimum = $5,
+				member_id = $6,
+				updated_at = $8
+			RETURNING
+				id, created_at
+	`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		t.Storage.ID,
+		t.Gsm,
+		t.Width,
+		t.MaterialNumber,
+		t.Minimum,
+		t.Member.ID,
+		t.CreatedAt,
+		t.UpdatedAt,
+	).Scan(&t.ID, &t.CreatedAt)
+
+	return err
+}
+
+func (r *repository) FindByID(ctx context.Context, thresholdID int64) (*model.Threshold, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, storage_id, gsm, width, material_number, minimum, member_id, created_at, updated_at
+			FROM
+				Threshold
+			WHERE
+				id = $1
+	`
+
+	t, err := scanThreshold(tx.QueryRowContext(ctx, query, thresholdID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, app.NewError(err, app.ENotFound)
+		}
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (r *repository) FindBySpec(ctx context.Context, spec *model.BasePaper) (*model.Threshold, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, storage_id, gsm, width, material_number, minimum, member_id, created_at, updated_at
+			FROM
+				Threshold
+			WHERE
+				storage_id = $1 AND gsm = $2 AND width = $3 AND material_number = $4
+			FOR UPDATE
+	`
+
+	t, err := scanThreshold(tx.QueryRowContext(ctx, query, spec.Storage.ID, spec.Gsm, spec.Width, spec.MaterialNumber))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, app.NewError(err, app.ENotFound)
+		}
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (r *repository) FindByStorageID(ctx context.Context, storageID int64) ([]*model.Threshold, error) {
+	query := `
+			SELECT
+				id, storage_id, gsm, width, material_number, minimum, member_id, created_at, updated_at
+			FROM
+				Threshold
+			WHERE
+				storage_id = $1
+			ORDER BY
+				gsm ASC, width ASC, material_number ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	thresholds := make([]*model.Threshold, 0)
+
+	for rows.Next() {
+		t, err := scanThreshold(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		thresholds = append(thresholds, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return thresholds, nil
+}
+
+func (r *repository) Delete(ctx context.Context, thresholdID int64) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			DELETE FROM
+				Threshold
+			WHERE
+				id = $1
+	`
+
+	res, err := tx.ExecContext(ctx, query, thresholdID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return app.NewError(nil, app.ENotFound)
+	}
+
+	return nil
+}
+
+func (r *repository) CreateAlert(ctx context.Context, alert *model.Alert) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			INSERT INTO
+				Alert
+				(storage_id, threshold_id, gsm, width, material_number, minimum, quantity, status, created_at, resolved_at)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			RETURNING
+				id
+	`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		alert.Storage.ID,
+		alert.Threshold.ID,
+		alert.Gsm,
+		alert.Width,
+		alert.MaterialNumber,
+		alert.Minimum,
+		alert.Quantity,
+		alert.Status,
+		alert.CreatedAt,
+		alert.ResolvedAt,
+	).Scan(&alert.ID)
+
+	return err
+}
+
+func (r *repository) FindOpenAlert(ctx context.Context, thresholdID int64) (*model.Alert, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			SELECT
+				id, storage_id, threshold_id, gsm, width, material_number, minimum, quantity, status, created_at, resolved_at
+			FROM
+				Alert
+			WHERE
+				threshold_id = $1 AND status = 'open'
+			FOR UPDATE
+	`
+
+	alert, err := scanAlert(tx.QueryRowContext(ctx, query, thresholdID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, app.NewError(err, app.ENotFound)
+		}
+		return nil, err
+	}
+
+	return alert, nil
+}
+
+func (r *repository) FindOpenAlertsByStorageID(ctx context.Context, storageID int64) ([]*model.Alert, error) {
+	query := `
+			SELECT
+				id, storage_id, threshold_id, gsm, width, material_number, minimum, quantity, status, created_at, resolved_at
+			FROM
+				Alert
+			WHERE
+				storage_id = $1 AND status = 'open'
+			ORDER BY
+				id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	alerts := make([]*model.Alert, 0)
+
+	for rows.Next() {
+		alert, err := scanAlert(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		alerts = append(alerts, alert)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+func (r *repository) UpdateAlert(ctx context.Context, alert *model.Alert) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			UPDATE
+				Alert
+			SET
+				quantity = $1,
+				status = $2,
+				resolved_at = $3
+			WHERE
+				id = $4
+	`
+
+	res, err := tx.ExecContext(ctx, query, alert.Quantity, alert.Status, alert.ResolvedAt, alert.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return app.NewError(nil, app.ENotFound)
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanThreshold(row scanner) (*model.Threshold, error) {
+	var t model.Threshold
+
+	err := row.Scan(
+		&t.ID,
+		&t.Storage.ID,
+		&t.Gsm,
+		&t.Width,
+		&t.MaterialNumber,
+		&t.Minimum,
+		&t.Member.ID,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func scanAlert(row scanner) (*model.Alert, error) {
+	var alert model.Alert
+
+	err := row.Scan(
+		&alert.ID,
+		&alert.Storage.ID,
+		&alert.Threshold.ID,
+		&alert.Gsm,
+		&alert.Width,
+		&alert.MaterialNumber,
+		&alert.Minimum,
+		&alert.Quantity,
+		&alert.Status,
+		&alert.CreatedAt,
+		&alert.ResolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &alert, nil
+}
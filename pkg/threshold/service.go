@@ -0,0 +1,15 @@
+package threshold
+
+import (
+	"context"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+type Service interface {
+	Set(ctx context.Context, req *SetThresholdRequest) (*ThresholdResponse, error)
+	GetByStorageID(ctx context.Context, storageID int64) ([]*ThresholdResponse, error)
+	Delete(ctx context.Context, thresholdID int64) error
+	GetOpenAlerts(ctx context.Context, storageID int64) ([]*AlertResponse, error)
+	Check(ctx context.Context, spec *model.BasePaper) error
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/basepaper"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	stormemb "github.com/bagus2x/tjiwi/pkg/storagemember"
+	"github.com/bagus2x/tjiwi/pkg/threshold"
+	"github.com/bagus2x/tjiwi/utils"
+)
+
+type service struct {
+	thresholdRepo threshold.Repository
+	basePaperRepo basepaper.Repository
+	storMembRepo  stormemb.Repository
+}
+
+func New(thresholdRepo threshold.Repository, basePaperRepo basepaper.Repository, storMembRepo stormemb.Repository) threshold.Service {
+	return &service{
+		thresholdRepo: thresholdRepo,
+		basePaperRepo: basePaperRepo,
+		storMembRepo:  storMembRepo,
+	}
+}
+
+func (s *service) Set(ctx context.Context, req *threshold.SetThresholdRequest) (*threshold.ThresholdResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, req.StorageID, memberID, true); err != nil {
+		return nil, err
+	}
+
+	t := model.Threshold{
+		Storage:        model.Storage{ID: req.StorageID},
+		Gsm:            req.Gsm,
+		Width:          req.Width,
+		MaterialNumber: req.MaterialNumber,
+		Minimum:        req.Minimum,
+		Member:         model.User{ID: memberID},
+		CreatedAt:      time.Now().Unix(),
+		UpdatedAt:      time.Now().Unix(),
+	}
+
+	err = s.basePaperRepo.WithTransaction(ctx, func(c context.Context) error {
+		err := s.thresholdRepo.Upsert(c, &t)
+		if err != nil {
+			return err
+		}
+
+		return s.Check(c, &model.BasePaper{
+			Storage:        t.Storage,
+			Gsm:            t.Gsm,
+			Width:          t.Width,
+			MaterialNumber: t.MaterialNumber,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toThresholdResponse(&t), nil
+}
+
+func (s *service) GetByStorageID(ctx context.Context, storageID int64) ([]*threshold.ThresholdResponse, error) {
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, storageID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	thresholds, err := s.thresholdRepo.FindByStorageID(ctx, storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*threshold.ThresholdResponse, 0)
+	for _, t := range thresholds {
+		res = append(res, toThresholdResponse(t))
+	}
+
+	return res, nil
+}
+
+func (s *service) Delete(ctx context.Context, thresholdID int64) error {
+	t, err := s.thresholdRepo.FindByID(ctx, thresholdID)
+	if app.ErrorCode(err) == app.ENotFound {
+		return app.NewError(nil, app.ENotFound, "Threshold not found")
+	} else if err != nil {
+		return err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, t.Storage.ID, memberID, true); err != nil {
+		return err
+	}
+
+	return s.thresholdRepo.Delete(ctx, t.ID)
+}
+
+func (s *service) GetOpenAlerts(ctx context.Context, storageID int64) ([]*threshold.AlertResponse, error) {
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, storageID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	alerts, err := s.thresholdRepo.FindOpenAlertsByStorageID(ctx, storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*threshold.AlertResponse, 0)
+	for _, alert := range alerts {
+		res = append(res, &threshold.AlertResponse{
+			ID:             alert.ID,
+			StorageID:      alert.Storage.ID,
+			ThresholdID:    alert.Threshold.ID,
+			Gsm:            alert.Gsm,
+			Width:          alert.Width,
+			MaterialNumber: alert.MaterialNumber,
+			Minimum:        alert.Minimum,
+			Quantity:       alert.Quantity,
+			Status:         alert.Status,
+			CreatedAt:      alert.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
+
+func (s *service) Check(ctx context.Context, spec *model.BasePaper) error {
+	t, err := s.thresholdRepo.FindBySpec(ctx, spec)
+	if app.ErrorCode(err) == app.ENotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	quantity, err := s.basePaperRepo.SumQuantityByMaterial(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	alert, err := s.thresholdRepo.FindOpenAlert(ctx, t.ID)
+	if err != nil && app.ErrorCode(err) != app.ENotFound {
+		return err
+	}
+
+	now := time.Now().Unix()
+
+	switch threshold.Evaluate(t.Minimum, quantity, alert != nil) {
+	case threshold.ActionRaise:
+		return s.thresholdRepo.CreateAlert(ctx, &model.Alert{
+			Storage:        t.Storage,
+			Threshold:      model.Threshold{ID: t.ID},
+			Gsm:            t.Gsm,
+			Width:          t.Width,
+			MaterialNumber: t.MaterialNumber,
+			Minimum:        t.Minimum,
+			Quantity:       quantity,
+			Status:         "open",
+			CreatedAt:      now,
+		})
+	case threshold.ActionResolve:
+		alert.Quantity = quantity
+		alert.Status = "resolved"
+		alert.ResolvedAt = db.NewNullInt(now, true)
+
+		return s.thresholdRepo.UpdateAlert(ctx, alert)
+	}
+
+	if alert != nil && alert.Quantity != quantity {
+		alert.Quantity = quantity
+
+		return s.thresholdRepo.UpdateAlert(ctx, alert)
+	}
+
+	return nil
+}
+
+func toThresholdResponse(t *model.Threshold) *threshold.ThresholdResponse {
+	return &threshold.ThresholdResponse{
+		ID:             t.ID,
+		StorageID:      t.Storage.ID,
+		Gsm:            t.Gsm,
+		Width:          t.Width,
+		MaterialNumber: t.MaterialNumber,
+		Minimum:        t.Minimum,
+		MemberID:       t.Member.ID,
+		CreatedAt:      t.CreatedAt,
+		UpdatedAt:      t.UpdatedAt,
+	}
+}
@@ -0,0 +1,46 @@
+package threshold
+
+import (
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/go-playground/validator/v10"
+)
+
+type SetThresholdRequest struct {
+	StorageID      int64 `json:"storageID" validate:"required"`
+	Gsm            int64 `json:"gsm" validate:"required"`
+	Width          int64 `json:"width" validate:"required"`
+	MaterialNumber int64 `json:"materialNumber" validate:"required"`
+	Minimum        int64 `json:"minimum" validate:"gte=0"`
+}
+
+func (r *SetThresholdRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type ThresholdResponse struct {
+	ID             int64 `json:"id"`
+	StorageID      int64 `json:"storageID"`
+	Gsm            int64 `json:"gsm"`
+	Width          int64 `json:"width"`
+	MaterialNumber int64 `json:"materialNumber"`
+	Minimum        int64 `json:"minimum"`
+	MemberID       int64 `json:"memberID"`
+	CreatedAt      int64 `json:"createdAt"`
+	UpdatedAt      int64 `json:"updatedAt"`
+}
+
+type AlertResponse struct {
+	ID             int64  `json:"id"`
+	StorageID      int64  `json:"storageID"`
+	ThresholdID    int64  `json:"thresholdID"`
+	Gsm            int64  `json:"gsm"`
+	Width          int64  `json:"width"`
+	MaterialNumber int64  `json:"materialNumber"`
+	Minimum        int64  `json:"minimum"`
+	Quantity       int64  `json:"quantity"`
+	Status         string `json:"status"`
+	CreatedAt      int64  `json:"createdAt"`
+}
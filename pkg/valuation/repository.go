@@ -0,0 +1,15 @@
+package valuation
+
+import (
+	"context"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, layer *model.CostLayer) error
+	FindOpenBySpec(ctx context.Context, spec *model.BasePaper) ([]*model.CostLayer, error)
+	FindLastBySpec(ctx context.Context, spec *model.BasePaper) (*model.CostLayer, error)
+	UpdateRemaining(ctx context.Context, layer *model.CostLayer) error
+	SumByMaterial(ctx context.Context, storageID int64, materialNumber *int64) ([]*MaterialTotals, error)
+}
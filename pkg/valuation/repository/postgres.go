@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/valuation"
+)
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) valuation.Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Create(ctx context.Context, layer *model.CostLayer) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			INSERT INTO
+				Cost_Layer
+				(storage_id, gsm, width, io, material_number, history_id, received, remaining, unit_cost, average_cost, created_at, updated_at)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			RETURNING
+				id
+	`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		layer.Storage.ID,
+		layer.Gsm,
+		layer.Width,
+		layer.Io,
+		layer.MaterialNumber,
+		layer.History,
+		layer.Received,
+		layer.Remaining,
+		layer.UnitCost,
+		layer.AverageCost,
+		layer.CreatedAt,
+		layer.UpdatedAt,
+	).Scan(&layer.ID)
+
+	return err
+}
+
+func (r *repository) FindOpenBySpec(ctx context.Context, spec *model.BasePaper) ([]*model.CostLayer, error) {
+	query := `
+			SELECT
+				id, storage_id, gsm, width, io, material_number, history_id, received, remaining, unit_cost, average_cost, created_at, updated_at
+			FROM
+				Cost_Layer
+			WHERE
+				storage_id = $1 AND gsm = $2 AND width = $3 AND io = $4 AND material_number = $5 AND remaining > 0
+			ORDER BY
+				created_at ASC, id ASC
+			FOR UPDATE
+	`
+
+	return r.findLayers(ctx, query, spec.Storage.ID, spec.Gsm, spec.Width, spec.Io, spec.MaterialNumber)
+}
+
+func (r *repository) FindLastBySpec(ctx context.Context, spec *model.BasePaper) (*model.CostLayer, error) {
+	query := `
+			SELECT
+				id, storage_id, gsm, width, io, material_number, history_id, received, remaining, unit_cost, average_cost, created_at, updated_at
+			FROM
+				Cost_Layer
+			WHERE
+				storage_id = $1 AND gsm = $2 AND width = $3 AND io = $4 AND material_number = $5 AND unit_cost IS NOT NULL
+			ORDER BY
+				created_at DESC, id DESC
+			LIMIT 1
+	`
+
+	layers, err := r.findLayers(ctx, query, spec.Storage.ID, spec.Gsm, spec.Width, spec.Io, spec.MaterialNumber)
+	if err != nil {
+		return nil, err
+	}
+	if len(layers) == 0 {
+		return nil, app.NewError(nil, app.ENotFound)
+	}
+
+	return layers[0], nil
+}
+
+func (r *repository) UpdateRemaining(ctx context.Context, layer *model.CostLayer) error {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			UPDATE
+				Cost_Layer
+			SET
+				remaining = $1,
+				updated_at = $2
+			WHERE
+				id = $3
+	`
+
+	res, err := tx.ExecContext(ctx, query, layer.Remaining, layer.UpdatedAt, layer.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return app.NewError(nil, app.ENotFound)
+	}
+
+	return nil
+}
+
+func (r *repository) SumByMaterial(ctx context.Context, storageID int64, materialNumber *int64) ([]*valuation.MaterialTotals, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	query := `
+			WITH average AS (
+				SELECT DISTINCT ON (gsm, width, io, material_number)
+					gsm, width, io, material_number, average_cost
+				FROM
+					Cost_Layer
+				WHERE
+					storage_id = $1 AND unit_cost IS NOT NULL AND ($2::INT IS NULL OR material_number = $2)
+				ORDER BY
+					gsm, width, io, material_number, created_at DESC, id DESC
+			)
+			SELECT
+				material_number, SUM(c.remaining), SUM(c.remaining * c.unit_cost), SUM(c.remaining * COALESCE(a.average_cost, c.unit_cost))
+			FROM
+				Cost_Layer c
+			JOIN
+				average a USING (gsm, width, io, material_number)
+			WHERE
+				c.storage_id = $1 AND c.unit_cost IS NOT NULL AND ($2::INT IS NULL OR material_number = $2)
+			GROUP BY
+				material_number
+	`
+
+	rows, err := tx.QueryContext(ctx, query, storageID, materialNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	totals := make(map[int64]*valuation.MaterialTotals)
+
+	for rows.Next() {
+		var t valuation.MaterialTotals
+
+		err := rows.Scan(&t.MaterialNumber, &t.Remaining, &t.RemainingCost, &t.AverageCost)
+		if err != nil {
+			return nil, err
+		}
+
+		totals[t.MaterialNumber] = &t
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	query = `
+			SELECT
+				material_number, SUM(quantity)
+			FROM
+				Base_Paper
+			WHERE
+				storage_id = $1 AND is_deleted = FALSE AND ($2::INT IS NULL OR material_number = $2)
+			GROUP BY
+				material_number
+	`
+
+	onHandRows, err := tx.QueryContext(ctx, query, storageID, materialNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	defer onHandRows.Close()
+
+	for onHandRows.Next() {
+		var number, onHand int64
+
+		if err := onHandRows.Scan(&number, &onHand); err != nil {
+			return nil, err
+		}
+
+		t, ok := totals[number]
+		if !ok {
+			t = &valuation.MaterialTotals{MaterialNumber: number}
+			totals[number] = t
+		}
+
+		t.OnHand = onHand
+	}
+
+	if err = onHandRows.Err(); err != nil {
+		return nil, err
+	}
+
+	res := make([]*valuation.MaterialTotals, 0)
+	for _, t := range totals {
+		res = append(res, t)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].MaterialNumber < res[j].MaterialNumber
+	})
+
+	return res, nil
+}
+
+func (r *repository) findLayers(ctx context.Context, query string, args ...interface{}) ([]*model.CostLayer, error) {
+	tx := db.AllowTransaction(r.db, ctx)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	layers := make([]*model.CostLayer, 0)
+
+	for rows.Next() {
+		var layer model.CostLayer
+
+		err := rows.Scan(
+			&layer.ID,
+			&layer.Storage.ID,
+			&layer.Gsm,
+			&layer.Width,
+			&layer.Io,
+			&layer.MaterialNumber,
+			&layer.History,
+			&layer.Received,
+			&layer.Remaining,
+			&layer.UnitCost,
+			&layer.AverageCost,
+			&layer.CreatedAt,
+			&layer.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		layers = append(layers, &layer)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return layers, nil
+}
@@ -0,0 +1,15 @@
+package valuation
+
+import (
+	"context"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+type Service interface {
+	Receive(ctx context.Context, spec *model.BasePaper, quantity int64, unitCost float64, historyID int64) error
+	Restore(ctx context.Context, spec *model.BasePaper, quantity int64, historyID int64) error
+	Issue(ctx context.Context, spec *model.BasePaper, quantity int64) ([]Issue, error)
+	IssueReceipt(ctx context.Context, spec *model.BasePaper, quantity int64, historyID int64) ([]Issue, error)
+	Valuate(ctx context.Context, req *ValuationRequest) (*ValuationResponse, error)
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/bagus2x/tjiwi/db"
+	"github.com/bagus2x/tjiwi/pkg/material"
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/bagus2x/tjiwi/pkg/storage"
+	stormemb "github.com/bagus2x/tjiwi/pkg/storagemember"
+	"github.com/bagus2x/tjiwi/pkg/valuation"
+	"github.com/bagus2x/tjiwi/utils"
+)
+
+type service struct {
+	valuationRepo valuation.Repository
+	storageRepo   storage.Repository
+	storMembRepo  stormemb.Repository
+	materialRepo  material.Repository
+}
+
+func New(valuationRepo valuation.Repository, storageRepo storage.Repository, storMembRepo stormemb.Repository, materialRepo material.Repository) valuation.Service {
+	return &service{
+		valuationRepo: valuationRepo,
+		storageRepo:   storageRepo,
+		storMembRepo:  storMembRepo,
+		materialRepo:  materialRepo,
+	}
+}
+
+func (s *service) Receive(ctx context.Context, spec *model.BasePaper, quantity int64, unitCost float64, historyID int64) error {
+	if quantity <= 0 {
+		return nil
+	}
+
+	layers, err := s.valuationRepo.FindOpenBySpec(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	average := sql.NullFloat64{}
+	last, err := s.valuationRepo.FindLastBySpec(ctx, spec)
+	if err != nil && app.ErrorCode(err) != app.ENotFound {
+		return err
+	}
+	if last != nil {
+		average = last.AverageCost
+	}
+
+	onHand, _ := valuation.CostedOnHand(layers)
+	now := time.Now().Unix()
+
+	return s.valuationRepo.Create(ctx, &model.CostLayer{
+		Storage:        model.Storage{ID: spec.Storage.ID},
+		Gsm:            spec.Gsm,
+		Width:          spec.Width,
+		Io:             spec.Io,
+		MaterialNumber: spec.MaterialNumber,
+		History:        db.NewNullInt(historyID, historyID != 0),
+		Received:       quantity,
+		Remaining:      quantity,
+		UnitCost:       sql.NullFloat64{Float64: valuation.RoundMoney(unitCost), Valid: true},
+		AverageCost:    sql.NullFloat64{Float64: valuation.MovingAverage(average, onHand, quantity, unitCost), Valid: true},
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	})
+}
+
+func (s *service) Restore(ctx context.Context, spec *model.BasePaper, quantity int64, historyID int64) error {
+	layers, err := s.valuationRepo.FindOpenBySpec(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	unitCost, ok := valuation.CurrentCost(layers)
+	if !ok {
+		last, err := s.valuationRepo.FindLastBySpec(ctx, spec)
+		if app.ErrorCode(err) == app.ENotFound {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		unitCost = last.UnitCost.Float64
+	}
+
+	return s.Receive(ctx, spec, quantity, unitCost, historyID)
+}
+
+func (s *service) Issue(ctx context.Context, spec *model.BasePaper, quantity int64) ([]valuation.Issue, error) {
+	if quantity <= 0 {
+		return make([]valuation.Issue, 0), nil
+	}
+
+	layers, err := s.valuationRepo.FindOpenBySpec(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.consume(ctx, layers, quantity)
+}
+
+func (s *service) IssueReceipt(ctx context.Context, spec *model.BasePaper, quantity int64, historyID int64) ([]valuation.Issue, error) {
+	if quantity <= 0 {
+		return make([]valuation.Issue, 0), nil
+	}
+
+	layers, err := s.valuationRepo.FindOpenBySpec(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.consume(ctx, valuation.ReceiptFirst(layers, historyID), quantity)
+}
+
+func (s *service) consume(ctx context.Context, layers []*model.CostLayer, quantity int64) ([]valuation.Issue, error) {
+	issues, short := valuation.Consume(layers, quantity)
+	consumed := make(map[int64]bool)
+	for _, issue := range issues {
+		consumed[issue.LayerID] = true
+	}
+
+	now := time.Now().Unix()
+	for _, layer := range layers {
+		if !consumed[layer.ID] {
+			continue
+		}
+
+		layer.UpdatedAt = now
+		err := s.valuationRepo.UpdateRemaining(ctx, layer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if short > 0 {
+		issues = append(issues, valuation.Issue{Quantity: short})
+	}
+
+	return issues, nil
+}
+
+func (s *service) Valuate(ctx context.Context, req *valuation.ValuationRequest) (*valuation.ValuationResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	memberID, err := utils.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stormemb.MustBeMember(ctx, s.storMembRepo, req.StorageID, memberID, false); err != nil {
+		return nil, err
+	}
+
+	method := req.Method
+	if method == "" {
+		st, err := s.storageRepo.FindByID(ctx, req.StorageID)
+		if app.ErrorCode(err) == app.ENotFound {
+			return nil, app.NewError(nil, app.ENotFound, "Storage not found")
+		} else if err != nil {
+			return nil, err
+		}
+
+		method = st.ValuationMethod
+	}
+
+	totals, err := s.valuationRepo.SumByMaterial(ctx, req.StorageID, req.MaterialNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers := make([]int64, 0)
+	for _, t := range totals {
+		numbers = append(numbers, t.MaterialNumber)
+	}
+
+	descriptions := make(map[int64]string)
+	if len(numbers) != 0 {
+		materials, err := s.materialRepo.FindByNumbers(ctx, req.StorageID, numbers)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range materials {
+			descriptions[m.Number] = m.Description
+		}
+	}
+
+	res := valuation.ValuationResponse{
+		StorageID: req.StorageID,
+		Method:    method,
+		Materials: make([]*valuation.MaterialValuationResponse, 0),
+	}
+
+	for _, t := range totals {
+		value, unitCost := valuation.Valuate(t, method)
+
+		uncosted := t.OnHand - t.Remaining
+		if uncosted < 0 {
+			uncosted = 0
+		}
+
+		res.Value = valuation.RoundMoney(res.Value + value)
+		res.Materials = append(res.Materials, &valuation.MaterialValuationResponse{
+			MaterialNumber:      t.MaterialNumber,
+			MaterialDescription: descriptions[t.MaterialNumber],
+			OnHand:              t.OnHand,
+			Costed:              t.Remaining,
+			Uncosted:            uncosted,
+			UnitCost:            unitCost,
+			Value:               value,
+		})
+	}
+
+	return &res, nil
+}
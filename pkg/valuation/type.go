@@ -0,0 +1,36 @@
+package valuation
+
+import (
+	"github.com/bagus2x/tjiwi/app"
+	"github.com/go-playground/validator/v10"
+)
+
+type ValuationRequest struct {
+	StorageID      int64  `form:"-" validate:"required"`
+	Method         string `form:"method" validate:"omitempty,oneof=fifo average"`
+	MaterialNumber *int64 `form:"material"`
+}
+
+func (r *ValuationRequest) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(r)
+
+	return app.ValidateAndTranslate(validate, err)
+}
+
+type MaterialValuationResponse struct {
+	MaterialNumber      int64   `json:"materialNumber"`
+	MaterialDescription string  `json:"materialDescription"`
+	OnHand              int64   `json:"onHand"`
+	Costed              int64   `json:"costed"`
+	Uncosted            int64   `json:"uncosted"`
+	UnitCost            float64 `json:"unitCost"`
+	Value               float64 `json:"value"`
+}
+
+type ValuationResponse struct {
+	StorageID int64                        `json:"storageID"`
+	Method    string                       `json:"method"`
+	Value     float64                      `json:"value"`
+	Materials []*MaterialValuationResponse `json:"materials"`
+}
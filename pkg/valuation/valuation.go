@@ -0,0 +1,122 @@
+package valuation
+
+import (
+	"database/sql"
+	"math"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+)
+
+const (
+	MethodFIFO    = "fifo"
+	MethodAverage = "average"
+)
+
+type Issue struct {
+	LayerID  int64
+	Quantity int64
+	UnitCost sql.NullFloat64
+}
+
+type MaterialTotals struct {
+	MaterialNumber int64
+	OnHand         int64
+	Remaining      int64
+	RemainingCost  float64
+	AverageCost    float64
+}
+
+func ValidMethod(method string) bool {
+	return method == MethodFIFO || method == MethodAverage
+}
+
+func RoundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func Consume(layers []*model.CostLayer, quantity int64) ([]Issue, int64) {
+	issues := make([]Issue, 0)
+
+	for _, layer := range layers {
+		if quantity <= 0 {
+			break
+		}
+		if layer.Remaining <= 0 {
+			continue
+		}
+
+		taken := layer.Remaining
+		if taken > quantity {
+			taken = quantity
+		}
+
+		layer.Remaining -= taken
+		quantity -= taken
+		issues = append(issues, Issue{LayerID: layer.ID, Quantity: taken, UnitCost: layer.UnitCost})
+	}
+
+	return issues, quantity
+}
+
+func ReceiptFirst(layers []*model.CostLayer, historyID int64) []*model.CostLayer {
+	ordered := make([]*model.CostLayer, 0, len(layers))
+	for _, layer := range layers {
+		if layer.History.Valid && layer.History.Int64 == historyID {
+			ordered = append(ordered, layer)
+		}
+	}
+	for _, layer := range layers {
+		if !layer.History.Valid || layer.History.Int64 != historyID {
+			ordered = append(ordered, layer)
+		}
+	}
+
+	return ordered
+}
+
+func CostedOnHand(layers []*model.CostLayer) (int64, float64) {
+	quantity := int64(0)
+	cost := 0.0
+
+	for _, layer := range layers {
+		if layer.Remaining <= 0 || !layer.UnitCost.Valid {
+			continue
+		}
+
+		quantity += layer.Remaining
+		cost += float64(layer.Remaining) * layer.UnitCost.Float64
+	}
+
+	return quantity, cost
+}
+
+func CurrentCost(layers []*model.CostLayer) (float64, bool) {
+	quantity, cost := CostedOnHand(layers)
+	if quantity == 0 {
+		return 0, false
+	}
+
+	return RoundMoney(cost / float64(quantity)), true
+}
+
+func MovingAverage(average sql.NullFloat64, onHand, quantity int64, unitCost float64) float64 {
+	if !average.Valid || onHand <= 0 {
+		return RoundMoney(unitCost)
+	}
+
+	total := average.Float64*float64(onHand) + unitCost*float64(quantity)
+
+	return RoundMoney(total / float64(onHand+quantity))
+}
+
+func Valuate(t *MaterialTotals, method string) (float64, float64) {
+	if t.Remaining == 0 {
+		return 0, 0
+	}
+
+	if method == MethodAverage {
+		return RoundMoney(t.AverageCost), RoundMoney(t.AverageCost / float64(t.Remaining))
+	}
+
+	return RoundMoney(t.RemainingCost), RoundMoney(t.RemainingCost / float64(t.Remaining))
+}
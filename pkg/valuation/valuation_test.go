@@ -0,0 +1,109 @@
+package valuation
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/bagus2x/tjiwi/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func cost(amount float64) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: amount, Valid: true}
+}
+
+func TestConsume(t *testing.T) {
+	layers := []*model.CostLayer{
+		{ID: 1, Remaining: 0, UnitCost: cost(5)},
+		{ID: 2, Remaining: 3, UnitCost: cost(10)},
+		{ID: 3, Remaining: 4, UnitCost: cost(12)},
+	}
+
+	issues, short := Consume(layers, 5)
+	assert.Equal(t, int64(0), short)
+	assert.Equal(t, []Issue{
+		{LayerID: 2, Quantity: 3, UnitCost: cost(10)},
+		{LayerID: 3, Quantity: 2, UnitCost: cost(12)},
+	}, issues)
+	assert.Equal(t, int64(0), layers[1].Remaining)
+	assert.Equal(t, int64(2), layers[2].Remaining)
+
+	issues, short = Consume(layers, 5)
+	assert.Equal(t, int64(3), short)
+	assert.Equal(t, []Issue{{LayerID: 3, Quantity: 2, UnitCost: cost(12)}}, issues)
+}
+
+func TestConsumeUncostedFirst(t *testing.T) {
+	layers := []*model.CostLayer{
+		{ID: 1, Remaining: 2},
+		{ID: 2, Remaining: 3, UnitCost: cost(10)},
+	}
+
+	issues, short := Consume(layers, 3)
+	assert.Equal(t, int64(0), short)
+	assert.Equal(t, []Issue{
+		{LayerID: 1, Quantity: 2},
+		{LayerID: 2, Quantity: 1, UnitCost: cost(10)},
+	}, issues)
+}
+
+func TestReceiptFirst(t *testing.T) {
+	layers := []*model.CostLayer{
+		{ID: 1, Remaining: 2, UnitCost: cost(8)},
+		{ID: 2, Remaining: 3, UnitCost: cost(10), History: sql.NullInt64{Int64: 7, Valid: true}},
+		{ID: 3, Remaining: 4, UnitCost: cost(12)},
+	}
+
+	issues, short := Consume(ReceiptFirst(layers, 7), 4)
+	assert.Equal(t, int64(0), short)
+	assert.Equal(t, []Issue{
+		{LayerID: 2, Quantity: 3, UnitCost: cost(10)},
+		{LayerID: 1, Quantity: 1, UnitCost: cost(8)},
+	}, issues)
+}
+
+func TestCurrentCost(t *testing.T) {
+	unitCost, ok := CurrentCost([]*model.CostLayer{
+		{Remaining: 1, UnitCost: cost(8)},
+		{Remaining: 0, UnitCost: cost(100)},
+		{Remaining: 5},
+		{Remaining: 3, UnitCost: cost(12)},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, 11.0, unitCost)
+
+	_, ok = CurrentCost([]*model.CostLayer{{Remaining: 5}})
+	assert.False(t, ok)
+
+	_, ok = CurrentCost(nil)
+	assert.False(t, ok)
+}
+
+func TestMovingAverage(t *testing.T) {
+	assert.Equal(t, 10.0, MovingAverage(sql.NullFloat64{}, 0, 4, 10))
+	assert.Equal(t, 10.0, MovingAverage(cost(8), 0, 4, 10))
+	assert.Equal(t, 11.5, MovingAverage(cost(10), 6, 2, 16))
+	assert.Equal(t, 11.33, MovingAverage(cost(10), 1, 2, 12))
+}
+
+func TestValuate(t *testing.T) {
+	totals := MaterialTotals{Remaining: 4, RemainingCost: 46, AverageCost: 40}
+
+	value, unitCost := Valuate(&totals, MethodFIFO)
+	assert.Equal(t, 46.0, value)
+	assert.Equal(t, 11.5, unitCost)
+
+	value, unitCost = Valuate(&totals, MethodAverage)
+	assert.Equal(t, 40.0, value)
+	assert.Equal(t, 10.0, unitCost)
+
+	value, unitCost = Valuate(&MaterialTotals{}, MethodAverage)
+	assert.Equal(t, 0.0, value)
+	assert.Equal(t, 0.0, unitCost)
+}
+
+func TestValidMethod(t *testing.T) {
+	assert.True(t, ValidMethod("fifo"))
+	assert.True(t, ValidMethod("average"))
+	assert.False(t, ValidMethod("lifo"))
+}